@@ -1,6 +1,8 @@
 package alerts
 
 import (
+	"fmt"
+	"strings"
 	"sync"
 	"time"
 )
@@ -40,6 +42,15 @@ const (
 	CategoryC2Disconnected
 	CategorySecurityBreach
 	CategorySystemNotice
+	CategoryWatchedHostAcquired  // Watch-listed host connected
+	CategoryWatchedHostEscalated // Watch-listed host gained privileges
+	CategoryWatchedHostLost      // Watch-listed host disconnected
+	CategoryFirstBlood           // First agent seen this run
+	CategoryBeaconResurrected    // Dead beacon checked in again
+	CategoryBeaconTaskStalled    // Beacon piling up tasks without completing any
+	CategoryAgentReconnected     // Agent dropped and came back under the same ID
+	CategoryPrivilegeLost        // Last privileged agent gone (fleet-wide)
+	CategoryPrivilegeRegained    // Privileged access after having none
 )
 
 // Alert represents a single alert/event
@@ -54,26 +65,150 @@ type Alert struct {
 	Timestamp time.Time
 	TTL       time.Duration // How long to display
 	IsNew     bool          // For animation purposes
+	Members   []string      // Agent names folded into a coalesced summary alert (oldest first)
+}
+
+// IsSummary reports whether the alert stands in for a burst of
+// same-category alerts (see AlertManager.SetCoalesce)
+func (a Alert) IsSummary() bool {
+	return len(a.Members) > 1
+}
+
+// TTLConfig controls how long alerts stay on screen. A category TTL
+// overrides the TTL of the alert's type.
+type TTLConfig struct {
+	Type     map[AlertType]time.Duration
+	Category map[AlertCategory]time.Duration
+}
+
+// DefaultTTLConfig returns the built-in alert TTLs
+func DefaultTTLConfig() TTLConfig {
+	return TTLConfig{
+		Type: map[AlertType]time.Duration{
+			AlertCritical: 35 * time.Second, // Was 30s
+			AlertWarning:  25 * time.Second, // Was 20s
+			AlertSuccess:  20 * time.Second, // Was 15s
+			AlertInfo:     15 * time.Second, // Was 10s
+			AlertNotice:   13 * time.Second, // Was 8s
+		},
+		// Category-specific TTL overrides for important events
+		Category: map[AlertCategory]time.Duration{
+			CategoryAgentConnected:            50 * time.Second, // Extended: 20s + 30s = 50s (legacy)
+			CategoryPrivilegedAccess:          50 * time.Second, // Extended: privilege escalation is critical
+			CategoryPrivilegedSessionOpened:   50 * time.Second, // Extended: privileged session init
+			CategoryPrivilegedSessionAcquired: 50 * time.Second, // Extended: new privileged session
+			CategoryPrivilegedBeaconAcquired:  50 * time.Second, // Extended: new privileged beacon
+			CategoryWatchedHostAcquired:       50 * time.Second, // Extended: operator asked to watch this host
+			CategoryWatchedHostEscalated:      50 * time.Second,
+			CategoryWatchedHostLost:           50 * time.Second,
+			CategoryFirstBlood:                50 * time.Second, // Extended: once-per-run engagement moment
+			CategoryBeaconResurrected:         50 * time.Second, // Extended: a box written off came back
+			CategoryPrivilegeLost:             50 * time.Second, // Extended: last admin foothold gone
+		},
+	}
+}
+
+// typeNames maps config names to alert types (used for TTL prefs)
+var typeNames = map[string]AlertType{
+	"critical": AlertCritical,
+	"warning":  AlertWarning,
+	"success":  AlertSuccess,
+	"info":     AlertInfo,
+	"notice":   AlertNotice,
+}
+
+// categoryNames maps config names to alert categories (used for TTL prefs)
+var categoryNames = map[string]AlertCategory{
+	"agent_connected":             CategoryAgentConnected,
+	"agent_disconnected":          CategoryAgentDisconnected,
+	"session_disconnected":        CategorySessionDisconnected,
+	"beacon_disconnected":         CategoryBeaconDisconnected,
+	"beacon_late":                 CategoryBeaconLate,
+	"beacon_missed":               CategoryBeaconMissed,
+	"beacon_task_queued":          CategoryBeaconTaskQueued,
+	"beacon_task_complete":        CategoryBeaconTaskComplete,
+	"privileged_access":           CategoryPrivilegedAccess,
+	"privileged_session_opened":   CategoryPrivilegedSessionOpened,
+	"session_opened":              CategorySessionOpened,
+	"privileged_session_acquired": CategoryPrivilegedSessionAcquired,
+	"session_acquired":            CategorySessionAcquired,
+	"privileged_beacon_acquired":  CategoryPrivilegedBeaconAcquired,
+	"beacon_acquired":             CategoryBeaconAcquired,
+	"session_closed":              CategorySessionClosed,
+	"c2_connected":                CategoryC2Connected,
+	"c2_disconnected":             CategoryC2Disconnected,
+	"security_breach":             CategorySecurityBreach,
+	"system_notice":               CategorySystemNotice,
+	"watched_host_acquired":       CategoryWatchedHostAcquired,
+	"watched_host_escalated":      CategoryWatchedHostEscalated,
+	"watched_host_lost":           CategoryWatchedHostLost,
+	"first_blood":                 CategoryFirstBlood,
+	"beacon_resurrected":          CategoryBeaconResurrected,
+	"beacon_task_stalled":         CategoryBeaconTaskStalled,
+	"agent_reconnected":           CategoryAgentReconnected,
+	"privilege_lost":              CategoryPrivilegeLost,
+	"privilege_regained":          CategoryPrivilegeRegained,
+}
+
+// Set overrides the TTL for an alert type or category by config name
+// (e.g. "critical" or "beacon_task_queued"). It reports false for
+// unknown names.
+func (c TTLConfig) Set(name string, ttl time.Duration) bool {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if alertType, ok := typeNames[name]; ok {
+		c.Type[alertType] = ttl
+		return true
+	}
+	if category, ok := categoryNames[name]; ok {
+		c.Category[category] = ttl
+		return true
+	}
+	return false
 }
 
 // AlertManager manages the alert queue
 type AlertManager struct {
 	alerts        []Alert
 	maxAlerts     int
+	ttls          TTLConfig
 	mu            sync.RWMutex
 	pulseState    int       // For animation: 0, 1, 2 (dim, normal, bright)
 	lastPulseAt   time.Time
 	pulseDuration time.Duration
 	expiredIndex  int       // Performance: track first non-expired alert index
+	raised        int       // Alerts accepted since creation (see Raised)
+	coalesceAfter int       // Same-category alerts in the window before they collapse (0 = never)
+}
+
+// Defaults for AlertManager.SetCoalesce
+const (
+	DefaultCoalesceAfter  = 3
+	CoalesceWindow        = 10 * time.Second // Alerts this close together count as one burst
+)
+
+// SetCoalesce sets how many alerts of one category may arrive within
+// CoalesceWindow before they collapse into a single summary alert, so a
+// bulk event (e.g. a mass deployment) doesn't flood the panel. 0 disables.
+func (am *AlertManager) SetCoalesce(after int) {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+	am.coalesceAfter = after
 }
 
-// NewAlertManager creates a new alert manager
+// NewAlertManager creates a new alert manager with the default TTLs
 func NewAlertManager(maxAlerts int) *AlertManager {
+	return NewAlertManagerWithTTLs(maxAlerts, DefaultTTLConfig())
+}
+
+// NewAlertManagerWithTTLs creates a new alert manager with custom TTLs
+func NewAlertManagerWithTTLs(maxAlerts int, ttls TTLConfig) *AlertManager {
 	return &AlertManager{
 		alerts:        make([]Alert, 0, maxAlerts),
 		maxAlerts:     maxAlerts,
+		ttls:          ttls,
 		pulseState:    0,
 		pulseDuration: 500 * time.Millisecond, // Pulse every 500ms
+		coalesceAfter: DefaultCoalesceAfter,
 	}
 }
 
@@ -87,33 +222,10 @@ func (am *AlertManager) AddAlertWithDetails(alertType AlertType, category AlertC
 	am.mu.Lock()
 	defer am.mu.Unlock()
 
-	// Set TTL based on alert type (increased by 5 seconds)
-	var ttl time.Duration
-	switch alertType {
-	case AlertCritical:
-		ttl = 35 * time.Second // Was 30s
-	case AlertWarning:
-		ttl = 25 * time.Second // Was 20s
-	case AlertSuccess:
-		ttl = 20 * time.Second // Was 15s
-	case AlertInfo:
-		ttl = 15 * time.Second // Was 10s
-	case AlertNotice:
-		ttl = 13 * time.Second // Was 8s
-	}
-	
-	// Category-specific TTL overrides for important events
-	switch category {
-	case CategoryAgentConnected:
-		ttl = 50 * time.Second // Extended: 20s + 30s = 50s (legacy)
-	case CategoryPrivilegedAccess:
-		ttl = 50 * time.Second // Extended: privilege escalation is critical
-	case CategoryPrivilegedSessionOpened:
-		ttl = 50 * time.Second // Extended: privileged session init
-	case CategoryPrivilegedSessionAcquired:
-		ttl = 50 * time.Second // Extended: new privileged session
-	case CategoryPrivilegedBeaconAcquired:
-		ttl = 50 * time.Second // Extended: new privileged beacon
+	// Set TTL based on alert type, with category-specific overrides
+	ttl := am.ttls.Type[alertType]
+	if categoryTTL, ok := am.ttls.Category[category]; ok {
+		ttl = categoryTTL
 	}
 
 	alert := Alert{
@@ -132,12 +244,18 @@ func (am *AlertManager) AddAlertWithDetails(alertType AlertType, category AlertC
 	// Check for duplicates (deduplication)
 	for i := range am.alerts {
 		if am.alerts[i].Category == category && 
-		   am.alerts[i].AgentName == agentName && 
+		   (am.alerts[i].AgentName == agentName || containsString(am.alerts[i].Members, agentName)) && 
 		   time.Since(am.alerts[i].Timestamp) < 5*time.Second {
 			// Duplicate within 5 seconds, skip
 			return
 		}
 	}
+	am.raised++
+
+	// Fold bursts of the same category into one summary alert
+	if am.coalesce(alert) {
+		return
+	}
 
 	// Add to front of queue
 	am.alerts = append([]Alert{alert}, am.alerts...)
@@ -148,6 +266,105 @@ func (am *AlertManager) AddAlertWithDetails(alertType AlertType, category AlertC
 	}
 }
 
+// coalesce folds alert into a summary of recent same-category alerts once
+// more than coalesceAfter have arrived within CoalesceWindow, reporting
+// whether it did. The summary moves to the front with a fresh timestamp.
+// Caller must hold the lock.
+func (am *AlertManager) coalesce(alert Alert) bool {
+	if am.coalesceAfter <= 0 {
+		return false
+	}
+
+	// Recent alerts of the same category, newest first
+	var burst []int
+	for i := range am.alerts {
+		if am.alerts[i].Category == alert.Category && alert.Timestamp.Sub(am.alerts[i].Timestamp) < CoalesceWindow {
+			burst = append(burst, i)
+		}
+	}
+	if len(burst) == 0 || (len(burst) < am.coalesceAfter && !am.alerts[burst[0]].IsSummary()) {
+		return false
+	}
+
+	// Merge oldest first so Members reads in arrival order
+	summary := alert
+	summary.ID = am.alerts[burst[0]].ID
+	summary.AgentID = "" // A summary has no single agent to jump to
+	summary.Details = ""
+	summary.Members = nil
+	for j := len(burst) - 1; j >= 0; j-- {
+		old := am.alerts[burst[j]]
+		if old.Type < summary.Type {
+			summary.Type = old.Type // Keep the most severe
+		}
+		if old.IsSummary() {
+			summary.Members = append(summary.Members, old.Members...)
+		} else {
+			summary.Members = append(summary.Members, old.AgentName)
+		}
+	}
+	summary.Members = append(summary.Members, alert.AgentName)
+	summary.AgentName = summaryText(alert.Category, len(summary.Members))
+	summary.Message = summary.AgentName
+
+	kept := make([]Alert, 0, len(am.alerts)-len(burst)+1)
+	kept = append(kept, summary)
+	for i := range am.alerts {
+		if am.alerts[i].Category != alert.Category || alert.Timestamp.Sub(am.alerts[i].Timestamp) >= CoalesceWindow {
+			kept = append(kept, am.alerts[i])
+		}
+	}
+	am.alerts = kept
+	return true
+}
+
+// summaryWords names what a coalesced burst of one category is about: the
+// plural noun and the verb in "5 beacons acquired"
+var summaryWords = map[AlertCategory][2]string{
+	CategoryAgentConnected:            {"agents", "connected"},
+	CategoryAgentDisconnected:         {"agents", "disconnected"},
+	CategorySessionDisconnected:       {"sessions", "lost"},
+	CategoryBeaconDisconnected:        {"beacons", "lost"},
+	CategoryBeaconLate:                {"beacons", "late"},
+	CategoryBeaconMissed:              {"beacons", "missed check-in"},
+	CategoryBeaconTaskQueued:          {"beacons", "tasked"},
+	CategoryBeaconTaskComplete:        {"beacons", "finished tasks"},
+	CategoryPrivilegedAccess:          {"agents", "escalated"},
+	CategoryPrivilegedSessionOpened:   {"privileged sessions", "opened"},
+	CategorySessionOpened:             {"sessions", "opened"},
+	CategoryPrivilegedSessionAcquired: {"privileged sessions", "acquired"},
+	CategorySessionAcquired:           {"sessions", "acquired"},
+	CategoryPrivilegedBeaconAcquired:  {"privileged beacons", "acquired"},
+	CategoryBeaconAcquired:            {"beacons", "acquired"},
+	CategorySessionClosed:             {"sessions", "closed"},
+	CategoryWatchedHostAcquired:       {"watched hosts", "acquired"},
+	CategoryWatchedHostEscalated:      {"watched hosts", "escalated"},
+	CategoryWatchedHostLost:           {"watched hosts", "lost"},
+	CategoryBeaconResurrected:         {"beacons", "resurrected"},
+	CategoryBeaconTaskStalled:         {"beacons", "stalled"},
+	CategoryAgentReconnected:          {"agents", "reconnected"},
+}
+
+// summaryText describes a coalesced burst of n alerts, e.g. "5 beacons
+// acquired"; categories without words fall back to "5 agents"
+func summaryText(category AlertCategory, n int) string {
+	words, ok := summaryWords[category]
+	if !ok {
+		return fmt.Sprintf("%d agents", n)
+	}
+	return fmt.Sprintf("%d %s %s", n, words[0], words[1])
+}
+
+// containsString reports whether list contains s
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
 // GetAlerts returns current alerts (removes expired ones)
 // Optimized to skip already-expired alerts using expiredIndex tracking
 func (am *AlertManager) GetAlerts() []Alert {
@@ -223,6 +440,29 @@ func (am *AlertManager) HasCriticalAlerts() bool {
 	return false
 }
 
+// CountCritical returns the number of unexpired critical alerts
+func (am *AlertManager) CountCritical() int {
+	am.mu.RLock()
+	defer am.mu.RUnlock()
+
+	now := time.Now()
+	count := 0
+	for i := range am.alerts {
+		if am.alerts[i].Type == AlertCritical && now.Sub(am.alerts[i].Timestamp) < am.alerts[i].TTL {
+			count++
+		}
+	}
+	return count
+}
+
+// Raised returns how many alerts have been accepted (after deduplication)
+// over the manager's lifetime, for measuring alert frequency
+func (am *AlertManager) Raised() int {
+	am.mu.RLock()
+	defer am.mu.RUnlock()
+	return am.raised
+}
+
 // ClearAll removes all alerts
 func (am *AlertManager) ClearAll() {
 	am.mu.Lock()
@@ -296,6 +536,24 @@ func (a Alert) GetLabel() string {
 		return "SECURITY ALERT"
 	case CategorySystemNotice:
 		return "SYSTEM NOTICE"
+	case CategoryWatchedHostAcquired:
+		return "WATCHED HOST ACQUIRED"
+	case CategoryWatchedHostEscalated:
+		return "WATCHED HOST ESCALATED"
+	case CategoryWatchedHostLost:
+		return "WATCHED HOST LOST"
+	case CategoryFirstBlood:
+		return "FIRST BLOOD"
+	case CategoryBeaconResurrected:
+		return "BEACON RESURRECTED"
+	case CategoryBeaconTaskStalled:
+		return "TASKS STALLED"
+	case CategoryAgentReconnected:
+		return "AGENT RECONNECTED"
+	case CategoryPrivilegeLost:
+		return "PRIVILEGED ACCESS LOST"
+	case CategoryPrivilegeRegained:
+		return "PRIVILEGED ACCESS REGAINED"
 	default:
 		return "EVENT"
 	}
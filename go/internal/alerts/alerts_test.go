@@ -0,0 +1,82 @@
+package alerts
+
+import (
+	"testing"
+	"time"
+)
+
+// alertNames returns the agent names of the alerts, in order
+func alertNames(alerts []Alert) []string {
+	names := make([]string, 0, len(alerts))
+	for _, alert := range alerts {
+		names = append(names, alert.AgentName)
+	}
+	return names
+}
+
+func TestCustomTTLExpiresAlert(t *testing.T) {
+	ttls := DefaultTTLConfig()
+	if !ttls.Set("warning", 30*time.Millisecond) {
+		t.Fatal("Set(warning) rejected")
+	}
+	if !ttls.Set("First_Blood ", 80*time.Millisecond) { // Names are trimmed and case-insensitive
+		t.Fatal("Set(first_blood) rejected")
+	}
+	if ttls.Set("no_such_alert", time.Second) {
+		t.Fatal("Set accepted an unknown name")
+	}
+
+	am := NewAlertManagerWithTTLs(5, ttls)
+	am.SetCoalesce(0)
+	am.AddAlert(AlertWarning, CategoryBeaconLate, "Beacon late", "late-host", "")
+	am.AddAlert(AlertSuccess, CategoryFirstBlood, "First agent acquired", "first-host", "")
+	am.AddAlert(AlertInfo, CategoryBeaconTaskComplete, "Task complete", "default-host", "")
+
+	if got := am.GetAlerts(); len(got) != 3 {
+		t.Fatalf("GetAlerts() = %v, want all three alerts", alertNames(got))
+	}
+
+	// Past the custom type TTL: only the warning has gone
+	time.Sleep(45 * time.Millisecond)
+	got := alertNames(am.GetAlerts())
+	if len(got) != 2 || got[0] != "default-host" || got[1] != "first-host" {
+		t.Fatalf("after 45ms GetAlerts() = %v, want [default-host first-host]", got)
+	}
+
+	// Past the category TTL, which overrides the success type TTL
+	time.Sleep(50 * time.Millisecond)
+	got = alertNames(am.GetAlerts())
+	if len(got) != 1 || got[0] != "default-host" {
+		t.Fatalf("after 95ms GetAlerts() = %v, want [default-host] (default TTL)", got)
+	}
+}
+
+func TestCoalescedSummaryText(t *testing.T) {
+	tests := []struct {
+		category AlertCategory
+		want     string
+	}{
+		{CategoryBeaconAcquired, "5 beacons acquired"},
+		{CategorySessionDisconnected, "5 sessions lost"},
+		{CategoryPrivilegedSessionAcquired, "5 privileged sessions acquired"},
+		{CategorySystemNotice, "5 agents"}, // No words for it: generic fallback
+	}
+	for _, tt := range tests {
+		am := NewAlertManager(10)
+		am.SetCoalesce(DefaultCoalesceAfter)
+		for _, host := range []string{"h1", "h2", "h3", "h4", "h5"} {
+			am.AddAlert(AlertInfo, tt.category, "event", host, host+"-id")
+		}
+
+		got := am.GetAlerts()
+		if len(got) != 1 || !got[0].IsSummary() {
+			t.Fatalf("category %d: GetAlerts() = %v, want one summary", tt.category, alertNames(got))
+		}
+		if got[0].AgentName != tt.want || got[0].Message != tt.want {
+			t.Errorf("category %d: summary = %q / %q, want %q", tt.category, got[0].AgentName, got[0].Message, tt.want)
+		}
+		if len(got[0].Members) != 5 {
+			t.Errorf("category %d: Members = %v, want all five hosts", tt.category, got[0].Members)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+	"time"
+)
+
+// postHandshakeWait is how long the probe waits after the TLS handshake for
+// the server to reject the client certificate (under TLS 1.3 the rejection
+// arrives as an alert after the handshake has already completed)
+const postHandshakeWait = 250 * time.Millisecond
+
+// certificateAlerts are the TLS alerts a server sends when it rejects the
+// client certificate: bad_certificate, unknown_ca and certificate_required
+var certificateAlerts = map[string]bool{
+	tls.AlertError(42).Error():  true,
+	tls.AlertError(48).Error():  true,
+	tls.AlertError(116).Error(): true,
+}
+
+// probeConnection dials target directly with the client's TLS config to
+// find out why the gRPC channel couldn't connect, returning an error that
+// names the actual cause. It returns nil if the probe connects fine.
+func probeConnection(ctx context.Context, target string, tlsConfig *tls.Config) error {
+	// Offer h2 like the gRPC transport does; servers enforcing ALPN reject
+	// handshakes without it
+	probeConfig := tlsConfig.Clone()
+	probeConfig.NextProtos = []string{"h2"}
+	dialer := &tls.Dialer{Config: probeConfig}
+	conn, err := dialer.DialContext(ctx, "tcp", target)
+	if err != nil {
+		return describeConnectError(target, err)
+	}
+	defer conn.Close()
+
+	// Give the server a moment to send a certificate alert
+	conn.SetReadDeadline(time.Now().Add(postHandshakeWait))
+	if _, err := conn.Read(make([]byte, 1)); err != nil && !errors.Is(err, os.ErrDeadlineExceeded) {
+		return describeConnectError(target, err)
+	}
+	return nil
+}
+
+// describeConnectError turns a dial/handshake error into an actionable message
+func describeConnectError(target string, err error) error {
+	var dnsErr *net.DNSError
+	var opErr *net.OpError
+	switch {
+	case errors.As(err, &dnsErr):
+		return fmt.Errorf("cannot resolve server host %q: %w", dnsErr.Name, err)
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return fmt.Errorf("connection refused at %s (is the multiplayer listener running?): %w", target, err)
+	case errors.Is(err, syscall.EHOSTUNREACH), errors.Is(err, syscall.ENETUNREACH):
+		return fmt.Errorf("no route to %s: %w", target, err)
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, os.ErrDeadlineExceeded):
+		return fmt.Errorf("no response from %s before the timeout (firewall or wrong port?): %w", target, err)
+	case errors.As(err, &opErr) && opErr.Op == "remote error" && certificateAlerts[opErr.Err.Error()]:
+		return fmt.Errorf("certificate rejected by %s (operator config revoked or for another server?): %w", target, err)
+	case errors.As(err, &opErr) && opErr.Op == "remote error": // Any other TLS alert
+		return fmt.Errorf("TLS handshake rejected by %s: %w", target, err)
+	default:
+		return fmt.Errorf("failed to connect to %s: %w", target, err)
+	}
+}
@@ -0,0 +1,28 @@
+package client
+
+import (
+	"crypto/tls"
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestDescribeConnectErrorTLSAlerts(t *testing.T) {
+	tests := []struct {
+		alert tls.AlertError
+		want  string
+	}{
+		{42, "certificate rejected"},    // bad_certificate
+		{48, "certificate rejected"},    // unknown_ca
+		{116, "certificate rejected"},   // certificate_required
+		{120, "TLS handshake rejected"}, // no_application_protocol
+		{70, "TLS handshake rejected"},  // protocol_version
+		{40, "TLS handshake rejected"},  // handshake_failure
+	}
+	for _, tt := range tests {
+		err := describeConnectError("127.0.0.1:31337", &net.OpError{Op: "remote error", Err: tt.alert})
+		if !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("alert %q: got %q, want %q", tt.alert.Error(), err, tt.want)
+		}
+	}
+}
@@ -16,7 +16,9 @@ import (
 	"github.com/bishopfox/sliver/protobuf/rpcpb"
 	"github.com/bishopfox/sliver/protobuf/sliverpb"
 	"github.com/musyoka101/sliver-graphs/internal/models"
+	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
 )
@@ -32,6 +34,92 @@ type SliverConfig struct {
 	Token         string `json:"token,omitempty"`
 }
 
+// DefaultDeadTolerance is how many missed intervals a beacon gets before it is
+// considered dead, for transports without a specific tolerance
+const DefaultDeadTolerance = 3.0
+
+// Options tunes how agents are fetched and classified
+type Options struct {
+	// DeadTolerance maps a transport substring (e.g. "dns") to the number of
+	// missed intervals before a beacon on that transport is considered dead.
+	// Slow transports like DNS need more slack than mTLS/HTTP.
+	DeadTolerance map[string]float64
+
+	// ConfigPath is an explicit operator config to use instead of
+	// auto-discovering one in ConfigDir (~/.sliver-client/configs)
+	ConfigPath string
+
+	// ConnectTimeout bounds connecting to the server; zero or out-of-range
+	// values fall back to DefaultConnectTimeout (see Timeout)
+	ConnectTimeout time.Duration
+
+	// PrivilegeOverrides reclassifies usernames regardless of the keyword
+	// heuristic: true forces privileged, false forces standard. Keys are
+	// lowercase, either a full "DOMAIN\user" or a bare account name.
+	PrivilegeOverrides map[string]bool
+}
+
+// Connect timeout bounds: anything outside them is treated as a typo
+const (
+	DefaultConnectTimeout = 10 * time.Second
+	MinConnectTimeout     = 500 * time.Millisecond
+	MaxConnectTimeout     = 5 * time.Minute
+)
+
+// Timeout returns the connect timeout to use, falling back to
+// DefaultConnectTimeout when ConnectTimeout is unset or out of range
+func (o Options) Timeout() time.Duration {
+	if o.ConnectTimeout < MinConnectTimeout || o.ConnectTimeout > MaxConnectTimeout {
+		return DefaultConnectTimeout
+	}
+	return o.ConnectTimeout
+}
+
+// DefaultOptions returns the options used when nothing is configured
+func DefaultOptions() Options {
+	return Options{
+		DeadTolerance: map[string]float64{
+			"dns": 6, // DNS beacons have long intervals and slow turnaround
+		},
+		PrivilegeOverrides: map[string]bool{},
+	}
+}
+
+// IsPrivileged classifies a user with the keyword heuristic, then applies
+// any PrivilegeOverrides entry. A full "DOMAIN\user" entry wins over one
+// for the bare account name.
+func (o Options) IsPrivileged(username, os string) bool {
+	privileged := isPrivileged(username, os)
+	userLower := strings.ToLower(strings.TrimSpace(username))
+	if override, ok := o.PrivilegeOverrides[userLower]; ok {
+		return override
+	}
+	if idx := strings.LastIndex(userLower, "\\"); idx != -1 {
+		if override, ok := o.PrivilegeOverrides[userLower[idx+1:]]; ok {
+			return override
+		}
+	}
+	return privileged
+}
+
+// ToleranceFor returns the dead-beacon tolerance (in intervals) for a transport.
+// If several keys match, the longest (most specific) one wins.
+func (o Options) ToleranceFor(transport string) float64 {
+	transportLower := strings.ToLower(transport)
+	result := DefaultDeadTolerance
+	matchedLen := 0
+	for key, tolerance := range o.DeadTolerance {
+		if key == "" || tolerance <= 0 || len(key) <= matchedLen {
+			continue
+		}
+		if strings.Contains(transportLower, key) {
+			result = tolerance
+			matchedLen = len(key)
+		}
+	}
+	return result
+}
+
 // SliverClient wraps the gRPC client
 type SliverClient struct {
 	config *SliverConfig
@@ -54,29 +142,78 @@ func LoadConfig(configPath string) (*SliverConfig, error) {
 	return &config, nil
 }
 
+// ConfigDirEnv names the environment variable that overrides the directory
+// operator configs are discovered in (e.g. a vault mount or container volume)
+const ConfigDirEnv = "SLIVER_CLIENT_DIR"
+
+// ConfigDir returns the directory operator configs are discovered in:
+// $SLIVER_CLIENT_DIR if set, otherwise ~/.sliver-client/configs
+func ConfigDir() (string, error) {
+	if dir := os.Getenv(ConfigDirEnv); dir != "" {
+		return dir, nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".sliver-client", "configs"), nil
+}
+
 // FindConfigFile looks for Sliver config in standard location
 func FindConfigFile() (string, error) {
-	homeDir, err := os.UserHomeDir()
+	configs, err := ListConfigFiles()
 	if err != nil {
 		return "", err
 	}
+	return configs[0], nil
+}
+
+// ListConfigFiles returns every .cfg in ConfigDir, sorted by name (the
+// first one is the auto-discovered default)
+func ListConfigFiles() ([]string, error) {
+	configDir, err := ConfigDir()
+	if err != nil {
+		return nil, err
+	}
 
-	configDir := filepath.Join(homeDir, ".sliver-client", "configs")
 	entries, err := os.ReadDir(configDir)
 	if err != nil {
-		return "", fmt.Errorf("config directory not found: %w", err)
+		return nil, fmt.Errorf("config directory not found: %w", err)
 	}
 
+	var configs []string
 	for _, entry := range entries {
 		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".cfg") {
-			return filepath.Join(configDir, entry.Name()), nil
+			configs = append(configs, filepath.Join(configDir, entry.Name()))
 		}
 	}
 
-	return "", fmt.Errorf("no .cfg files found in %s", configDir)
+	if len(configs) == 0 {
+		return nil, fmt.Errorf("no .cfg files found in %s", configDir)
+	}
+	return configs, nil
+}
+
+// ResolveConfigPath returns configPath if it is set (erroring if the file
+// doesn't exist), otherwise the auto-discovered config
+func ResolveConfigPath(configPath string) (string, error) {
+	if configPath == "" {
+		return FindConfigFile()
+	}
+	info, err := os.Stat(configPath)
+	if err != nil {
+		return "", fmt.Errorf("config file %s: %w", configPath, err)
+	}
+	if info.IsDir() {
+		return "", fmt.Errorf("config file %s is a directory", configPath)
+	}
+	return configPath, nil
 }
 
-// Connect establishes a connection to the Sliver server
+// Connect establishes a connection to the Sliver server, giving up when
+// ctx is done (callers bound it with Options.Timeout). On failure the
+// connection is probed directly so the error names the real cause
+// (refused, unresolvable host, rejected certificate) rather than a deadline.
 func (c *SliverClient) Connect(ctx context.Context) error {
 	// Create TLS credentials
 	tlsConfig, err := c.buildTLSConfig()
@@ -88,15 +225,25 @@ func (c *SliverClient) Connect(ctx context.Context) error {
 
 	// Connect to server
 	target := fmt.Sprintf("%s:%d", c.config.LHost, c.config.LPort)
-	conn, err := grpc.DialContext(
-		ctx,
-		target,
-		grpc.WithTransportCredentials(creds),
-		grpc.WithBlock(),
-		grpc.WithTimeout(10*time.Second),
-	)
+	conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(creds))
 	if err != nil {
-		return fmt.Errorf("failed to connect to %s: %w", target, err)
+		return fmt.Errorf("invalid server address %s: %w", target, err)
+	}
+
+	// NewClient connects lazily; connect now and wait for the channel so
+	// failures surface here instead of on the first RPC
+	conn.Connect()
+	for state := conn.GetState(); state != connectivity.Ready; state = conn.GetState() {
+		if state == connectivity.TransientFailure || !conn.WaitForStateChange(ctx, state) {
+			conn.Close()
+			if ctx.Err() != nil {
+				return describeConnectError(target, ctx.Err())
+			}
+			if err := probeConnection(ctx, target, tlsConfig); err != nil {
+				return err
+			}
+			return fmt.Errorf("failed to connect to %s: gRPC channel in state %s", target, state)
+		}
 	}
 
 	c.conn = conn
@@ -166,6 +313,24 @@ func (c *SliverClient) GetBeacons(ctx context.Context) ([]*clientpb.Beacon, erro
 	return resp.Beacons, nil
 }
 
+// GetOperators fetches the operators known to the server
+func (c *SliverClient) GetOperators(ctx context.Context) ([]*clientpb.Operator, error) {
+	// Add token to context if available
+	if c.config.Token != "" {
+		md := metadata.New(map[string]string{
+			"Authorization": "Bearer " + c.config.Token,
+		})
+		ctx = metadata.NewOutgoingContext(ctx, md)
+	}
+
+	resp, err := c.rpc.GetOperators(ctx, &commonpb.Empty{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operators: %w", err)
+	}
+
+	return resp.Operators, nil
+}
+
 // Close closes the connection
 func (c *SliverClient) Close() error {
 	if c.conn != nil {
@@ -181,8 +346,27 @@ func NewSliverClient(config *SliverConfig) *SliverClient {
 	}
 }
 
+// unknownField is the placeholder for OS/transport values the server didn't send
+const unknownField = "unknown"
+
+// fillMissingFields marks agents the server sent without OS, transport or
+// address (agents mid-handshake) as Incomplete and defaults the OS and
+// transport so they bucket as "unknown" rather than rendering as blanks.
+// An empty RemoteAddress is left as-is (the UI has its own placeholder).
+func fillMissingFields(agent *models.Agent) {
+	if agent.OS == "" || agent.Transport == "" || agent.RemoteAddress == "" {
+		agent.Incomplete = true
+	}
+	if agent.OS == "" {
+		agent.OS = unknownField
+	}
+	if agent.Transport == "" {
+		agent.Transport = unknownField
+	}
+}
+
 // ConvertToAgents converts Sliver sessions and beacons to our models.Agent type
-func ConvertToAgents(sessions []*clientpb.Session, beacons []*clientpb.Beacon, client *SliverClient) ([]models.Agent, models.Stats) {
+func ConvertToAgents(sessions []*clientpb.Session, beacons []*clientpb.Beacon, client *SliverClient, opts Options) ([]models.Agent, models.Stats) {
 	var agents []models.Agent
 	hostMap := make(map[string]bool)
 
@@ -196,7 +380,7 @@ func ConvertToAgents(sessions []*clientpb.Session, beacons []*clientpb.Beacon, c
 			Transport:     s.Transport,
 			RemoteAddress: s.RemoteAddress,
 			IsSession:     true,
-			IsPrivileged:  isPrivileged(s.Username, s.OS),
+			IsPrivileged:  opts.IsPrivileged(s.Username, s.OS),
 			IsDead:        false,
 			ProxyURL:      s.ProxyURL,
 			// Additional fields
@@ -213,6 +397,7 @@ func ConvertToAgents(sessions []*clientpb.Session, beacons []*clientpb.Beacon, c
 		// Domain will be populated asynchronously in the background
 		// (no blocking queries here to keep UI responsive)
 		
+		fillMissingFields(&agent)
 		agents = append(agents, agent)
 		hostMap[s.Hostname] = true
 	}
@@ -220,14 +405,15 @@ func ConvertToAgents(sessions []*clientpb.Session, beacons []*clientpb.Beacon, c
 	// Convert beacons
 	for _, b := range beacons {
 		// Calculate if beacon is dead based on last check-in time
-		// A beacon is considered dead if it hasn't checked in for 3x its interval
+		// A beacon is considered dead if it hasn't checked in for N x its interval,
+		// where N depends on the transport (3x by default, more for slow DNS)
 		// Note: b.Interval is in nanoseconds (time.Duration)
 		isDead := b.IsDead
 		
 		if !isDead && b.LastCheckin > 0 && b.Interval > 0 {
 			lastCheckin := time.Unix(b.LastCheckin, 0)
 			// Interval is already a time.Duration in nanoseconds, don't multiply by time.Second
-			deadThreshold := time.Duration(b.Interval * 3)
+			deadThreshold := time.Duration(float64(b.Interval) * opts.ToleranceFor(b.Transport))
 			timeSinceCheckin := time.Since(lastCheckin)
 			
 			if timeSinceCheckin > deadThreshold {
@@ -243,7 +429,7 @@ func ConvertToAgents(sessions []*clientpb.Session, beacons []*clientpb.Beacon, c
 			Transport:     b.Transport,
 			RemoteAddress: b.RemoteAddress,
 			IsSession:     false,
-			IsPrivileged:  isPrivileged(b.Username, b.OS),
+			IsPrivileged:  opts.IsPrivileged(b.Username, b.OS),
 			IsDead:        isDead,
 			ProxyURL:      b.ProxyURL,
 			// Additional fields
@@ -260,7 +446,9 @@ func ConvertToAgents(sessions []*clientpb.Session, beacons []*clientpb.Beacon, c
 			LastCheckin:    b.LastCheckin,
 			Evasion:        b.Evasion,
 			Burned:         b.Burned,
+			TrafficPerHour: EstimateBeaconTraffic(b.Interval, b.Jitter),
 		}
+		fillMissingFields(&agent)
 		agents = append(agents, agent)
 		hostMap[b.Hostname] = true
 	}
@@ -271,10 +459,34 @@ func ConvertToAgents(sessions []*clientpb.Session, beacons []*clientpb.Beacon, c
 		Hosts:       len(hostMap),
 		Compromised: len(agents),
 	}
+	for _, agent := range agents {
+		if agent.IsPrivileged {
+			stats.Privileged++
+		}
+	}
 
 	return agents, stats
 }
 
+// Rough on-the-wire sizes used for traffic estimates (Sliver reports no
+// byte counters): one beacon check-in poll and one task round trip
+const (
+	BeaconCheckinBytes = 1024
+	BeaconTaskBytes    = 8 * 1024
+)
+
+// EstimateBeaconTraffic estimates a beacon's check-in traffic in bytes per
+// hour from its interval and jitter (both nanoseconds; jitter adds on
+// average half its value to each sleep). 0 if the interval is unknown.
+func EstimateBeaconTraffic(interval, jitter int64) int64 {
+	sleep := time.Duration(interval + jitter/2)
+	if sleep <= 0 {
+		return 0
+	}
+	checkinsPerHour := float64(time.Hour) / float64(sleep)
+	return int64(checkinsPerHour * BeaconCheckinBytes)
+}
+
 // isPrivileged checks if a user is privileged
 func isPrivileged(username, os string) bool {
 	userLower := strings.ToLower(username)
@@ -293,48 +505,106 @@ func isPrivileged(username, os string) bool {
 	return false
 }
 
-// FetchAgents connects to Sliver and fetches all agents
-func FetchAgents(ctx context.Context) ([]models.Agent, models.Stats, error) {
-	// Find config file
-	configPath, err := FindConfigFile()
+// FetchAgents connects to Sliver and fetches all agents, plus the names of
+// the operators online. Operators are best-effort: if the server doesn't
+// answer that call the names are nil and the fetch still succeeds.
+func FetchAgents(ctx context.Context, opts Options) ([]models.Agent, models.Stats, []string, error) {
+	// Find config file (explicit path or auto-discovery)
+	configPath, err := ResolveConfigPath(opts.ConfigPath)
 	if err != nil {
-		return nil, models.Stats{}, fmt.Errorf("config not found: %w", err)
+		return nil, models.Stats{}, nil, fmt.Errorf("config not found: %w", err)
 	}
 
 	// Load config
 	config, err := LoadConfig(configPath)
 	if err != nil {
-		return nil, models.Stats{}, fmt.Errorf("failed to load config: %w", err)
+		return nil, models.Stats{}, nil, fmt.Errorf("failed to load config: %w", err)
 	}
 
 	// Create client
 	client := NewSliverClient(config)
 
 	// Connect with timeout
-	connectCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	timeout := opts.Timeout()
+	connectCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	if err := client.Connect(connectCtx); err != nil {
-		return nil, models.Stats{}, fmt.Errorf("connection failed: %w", err)
+		return nil, models.Stats{}, nil, fmt.Errorf("connection failed (timeout %s): %w", timeout, err)
 	}
 	defer client.Close()
 
-	// Fetch sessions and beacons
-	sessions, err := client.GetSessions(ctx)
+	sessions, beacons, operators, err := fetchFleet(ctx, client, timeout)
 	if err != nil {
-		return nil, models.Stats{}, fmt.Errorf("failed to get sessions: %w", err)
+		return nil, models.Stats{}, nil, err
 	}
 
-	beacons, err := client.GetBeacons(ctx)
-	if err != nil {
-		return nil, models.Stats{}, fmt.Errorf("failed to get beacons: %w", err)
+	// Convert to our models.Agent type
+	agents, stats := ConvertToAgents(sessions, beacons, client, opts)
+
+	var online []string
+	if operators != nil {
+		online = []string{}
+		for _, operator := range operators {
+			if operator.Online {
+				online = append(online, operator.Name)
+			}
+		}
 	}
 
-	// Convert to our models.Agent type
-	agents, stats := ConvertToAgents(sessions, beacons, client)
+	return agents, stats, online, nil
+}
+
+// operatorsTimeout bounds the best-effort operators call, so a slow or
+// unimplemented RPC can't hold up the sessions and beacons it runs beside
+const operatorsTimeout = 2 * time.Second
+
+// fetchFleet fetches sessions, beacons and operators concurrently, so the
+// latency is the slowest round-trip instead of their sum. Either of the
+// first two failing fails the fetch and cancels the other; operators are
+// optional (nil on failure or after operatorsTimeout). Each call is bounded
+// by timeout on its own, so a hung server stalls a refresh for one timeout.
+func fetchFleet(ctx context.Context, client *SliverClient, timeout time.Duration) ([]*clientpb.Session, []*clientpb.Beacon, []*clientpb.Operator, error) {
+	var (
+		sessions  []*clientpb.Session
+		beacons   []*clientpb.Beacon
+		operators []*clientpb.Operator
+	)
 
-	return agents, stats, nil
+	opsDone := make(chan struct{})
+	go func() {
+		defer close(opsDone)
+		opsCtx, cancel := context.WithTimeout(ctx, min(timeout, operatorsTimeout))
+		defer cancel()
+		operators, _ = client.GetOperators(opsCtx)
+	}()
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		callCtx, cancel := context.WithTimeout(gctx, timeout)
+		defer cancel()
+		var err error
+		if sessions, err = client.GetSessions(callCtx); err != nil {
+			return fmt.Errorf("failed to get sessions: %w", err)
+		}
+		return nil
+	})
+	g.Go(func() error {
+		callCtx, cancel := context.WithTimeout(gctx, timeout)
+		defer cancel()
+		var err error
+		if beacons, err = client.GetBeacons(callCtx); err != nil {
+			return fmt.Errorf("failed to get beacons: %w", err)
+		}
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		return nil, nil, nil, err
+	}
+	<-opsDone
+	return sessions, beacons, operators, nil
 }
+
 // QueryDomainFromSession queries the DNS domain from a session agent (exported for background queries)
 // Returns the DNS domain (e.g., "m3c.local") or empty string if not found
 func (c *SliverClient) QueryDomainFromSession(ctx context.Context, sessionID string) string {
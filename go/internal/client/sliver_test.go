@@ -0,0 +1,198 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bishopfox/sliver/protobuf/clientpb"
+	"github.com/bishopfox/sliver/protobuf/commonpb"
+	"github.com/bishopfox/sliver/protobuf/rpcpb"
+	"google.golang.org/grpc"
+)
+
+// slowRPC answers the fleet calls after a fixed round-trip delay, or fails
+// early when the context is done. Other SliverRPCClient methods are not
+// implemented (the nil embed panics).
+type slowRPC struct {
+	rpcpb.SliverRPCClient
+	delay          time.Duration
+	sessionsDelay  time.Duration // overrides delay for GetSessions when set
+	operatorsDelay time.Duration // overrides delay for GetOperators when set
+	beaconsErr     error
+}
+
+// wait sleeps for d, returning the context's error if it ends first
+func wait(ctx context.Context, d time.Duration) error {
+	select {
+	case <-time.After(d):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r slowRPC) GetSessions(ctx context.Context, _ *commonpb.Empty, _ ...grpc.CallOption) (*clientpb.Sessions, error) {
+	delay := r.delay
+	if r.sessionsDelay > 0 {
+		delay = r.sessionsDelay
+	}
+	if err := wait(ctx, delay); err != nil {
+		return nil, err
+	}
+	return &clientpb.Sessions{Sessions: []*clientpb.Session{{ID: "s1"}}}, nil
+}
+
+func (r slowRPC) GetBeacons(ctx context.Context, _ *commonpb.Empty, _ ...grpc.CallOption) (*clientpb.Beacons, error) {
+	if err := wait(ctx, r.delay); err != nil {
+		return nil, err
+	}
+	if r.beaconsErr != nil {
+		return nil, r.beaconsErr
+	}
+	return &clientpb.Beacons{Beacons: []*clientpb.Beacon{{ID: "b1"}, {ID: "b2"}}}, nil
+}
+
+func (r slowRPC) GetOperators(ctx context.Context, _ *commonpb.Empty, _ ...grpc.CallOption) (*clientpb.Operators, error) {
+	delay := r.delay
+	if r.operatorsDelay > 0 {
+		delay = r.operatorsDelay
+	}
+	if err := wait(ctx, delay); err != nil {
+		return nil, err
+	}
+	return &clientpb.Operators{Operators: []*clientpb.Operator{{Name: "alice", Online: true}}}, nil
+}
+
+// newSlowClient returns a client whose RPCs each take delay
+func newSlowClient(delay time.Duration) *SliverClient {
+	return &SliverClient{config: &SliverConfig{}, rpc: slowRPC{delay: delay}}
+}
+
+func TestFetchFleetConcurrent(t *testing.T) {
+	const delay = 50 * time.Millisecond
+	start := time.Now()
+	sessions, beacons, operators, err := fetchFleet(context.Background(), newSlowClient(delay), time.Second)
+	elapsed := time.Since(start)
+
+	if err != nil {
+		t.Fatalf("fetchFleet: %v", err)
+	}
+	if len(sessions) != 1 || len(beacons) != 2 || len(operators) != 1 {
+		t.Fatalf("fetchFleet = %d sessions, %d beacons, %d operators; want 1, 2, 1",
+			len(sessions), len(beacons), len(operators))
+	}
+	// Three sequential round-trips would take 3*delay
+	if elapsed >= 2*delay {
+		t.Errorf("fetchFleet took %s, want about one round-trip (%s)", elapsed, delay)
+	}
+}
+
+func TestFetchFleetFailsOnBeaconError(t *testing.T) {
+	client := &SliverClient{config: &SliverConfig{}, rpc: slowRPC{beaconsErr: errors.New("unavailable")}}
+	if _, _, _, err := fetchFleet(context.Background(), client, time.Second); err == nil {
+		t.Fatal("fetchFleet succeeded with a failed GetBeacons")
+	}
+}
+
+func TestFetchFleetBeaconErrorCancelsSessions(t *testing.T) {
+	rpc := slowRPC{sessionsDelay: time.Minute, beaconsErr: errors.New("unavailable")}
+	client := &SliverClient{config: &SliverConfig{}, rpc: rpc}
+	start := time.Now()
+	if _, _, _, err := fetchFleet(context.Background(), client, time.Second); err == nil {
+		t.Fatal("fetchFleet succeeded with a failed GetBeacons")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("fetchFleet took %s, want GetSessions cancelled by the GetBeacons failure", elapsed)
+	}
+}
+
+func TestFetchFleetBoundsEachCall(t *testing.T) {
+	const timeout = 50 * time.Millisecond
+	client := &SliverClient{config: &SliverConfig{}, rpc: slowRPC{sessionsDelay: time.Minute}}
+	start := time.Now()
+	_, _, _, err := fetchFleet(context.Background(), client, timeout)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("fetchFleet error = %v, want a deadline from the hung GetSessions", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*timeout {
+		t.Errorf("fetchFleet took %s, want about one per-call timeout (%s)", elapsed, timeout)
+	}
+}
+
+func TestFetchFleetSlowOperators(t *testing.T) {
+	rpc := slowRPC{delay: 10 * time.Millisecond, operatorsDelay: time.Minute}
+	client := &SliverClient{config: &SliverConfig{}, rpc: rpc}
+	start := time.Now()
+	sessions, beacons, operators, err := fetchFleet(context.Background(), client, time.Second)
+	elapsed := time.Since(start)
+
+	if err != nil {
+		t.Fatalf("fetchFleet: %v", err)
+	}
+	if len(sessions) != 1 || len(beacons) != 2 || operators != nil {
+		t.Fatalf("fetchFleet = %d sessions, %d beacons, %v operators; want 1, 2, nil",
+			len(sessions), len(beacons), operators)
+	}
+	if elapsed > operatorsTimeout+time.Second {
+		t.Errorf("fetchFleet took %s, want operators bounded by %s", elapsed, operatorsTimeout)
+	}
+}
+
+func BenchmarkFetchFleet(b *testing.B) {
+	client := newSlowClient(5 * time.Millisecond)
+	ctx := context.Background()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, _, err := fetchFleet(ctx, client, time.Second); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkFetchFleetSequential is the old one-call-after-another path,
+// for comparison with BenchmarkFetchFleet
+func BenchmarkFetchFleetSequential(b *testing.B) {
+	client := newSlowClient(5 * time.Millisecond)
+	ctx := context.Background()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := client.GetSessions(ctx); err != nil {
+			b.Fatal(err)
+		}
+		if _, err := client.GetBeacons(ctx); err != nil {
+			b.Fatal(err)
+		}
+		client.GetOperators(ctx)
+	}
+}
+
+func TestPrivilegeOverrides(t *testing.T) {
+	opts := DefaultOptions()
+	if !opts.IsPrivileged(`NT AUTHORITY\SYSTEM`, "windows") {
+		t.Fatal("heuristic no longer flags SYSTEM; the override cases below prove nothing")
+	}
+
+	opts.PrivilegeOverrides[`nt authority\system`] = false
+	opts.PrivilegeOverrides["system"] = true
+	opts.PrivilegeOverrides["svc_backup"] = true
+	opts.PrivilegeOverrides["administrator"] = false
+
+	tests := []struct {
+		username string
+		want     bool
+	}{
+		{`NT AUTHORITY\SYSTEM`, false},  // Full-name override forces standard, beating the bare "system" key
+		{`nt authority\system `, false}, // Case and surrounding space don't matter
+		{`WS01\SYSTEM`, true},           // Only the bare-name key applies
+		{`CORP\svc_backup`, true},       // Bare-name override forces privileged
+		{`CORP\Administrator`, false},   // Bare-name override forces standard
+		{`CORP\alice`, false},           // No override: heuristic
+	}
+	for _, tt := range tests {
+		if got := opts.IsPrivileged(tt.username, "windows"); got != tt.want {
+			t.Errorf("IsPrivileged(%q) = %v, want %v", tt.username, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"sync"
+	"time"
+)
+
+// TTLCache is a size-bounded string cache whose entries expire, so lookups
+// (reverse DNS, session domains) are redone now and then instead of being
+// remembered forever. Failed lookups (empty values) expire sooner so a
+// host that later gets a DNS entry picks it up.
+type TTLCache struct {
+	entries map[string]cacheEntry
+	maxSize int
+	ttl     time.Duration // Lifetime of a found value
+	missTTL time.Duration // Lifetime of an empty (failed) value
+	mutex   sync.Mutex
+}
+
+// cacheEntry is one cached value and when it stops being served
+type cacheEntry struct {
+	value   string
+	expires time.Time
+}
+
+// NewTTLCache creates a cache holding at most maxSize entries
+func NewTTLCache(maxSize int, ttl, missTTL time.Duration) *TTLCache {
+	return &TTLCache{
+		entries: make(map[string]cacheEntry),
+		maxSize: maxSize,
+		ttl:     ttl,
+		missTTL: missTTL,
+	}
+}
+
+// Get returns the cached value for key; ok is false if it is missing or
+// has expired (expired entries are dropped, so the caller re-resolves)
+func (c *TTLCache) Get(key string) (value string, ok bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, exists := c.entries[key]
+	if !exists {
+		return "", false
+	}
+	if !time.Now().Before(entry.expires) {
+		delete(c.entries, key)
+		return "", false
+	}
+	return entry.value, true
+}
+
+// Set caches value for key (an empty value is a failed lookup and gets the
+// shorter miss TTL). When full, expired entries are dropped first, then the
+// one closest to expiring.
+func (c *TTLCache) Set(key, value string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := time.Now()
+	ttl := c.ttl
+	if value == "" {
+		ttl = c.missTTL
+	}
+
+	if _, exists := c.entries[key]; !exists && c.maxSize > 0 && len(c.entries) >= c.maxSize {
+		c.evict(now)
+	}
+	c.entries[key] = cacheEntry{value: value, expires: now.Add(ttl)}
+}
+
+// evict makes room for one entry. Caller must hold the lock.
+func (c *TTLCache) evict(now time.Time) {
+	oldestKey := ""
+	var oldest time.Time
+	for key, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, key)
+			continue
+		}
+		if oldestKey == "" || entry.expires.Before(oldest) {
+			oldestKey, oldest = key, entry.expires
+		}
+	}
+	if len(c.entries) >= c.maxSize {
+		delete(c.entries, oldestKey)
+	}
+}
+
+// PurgeMisses drops every cached failed lookup (empty value), keeping found
+// values, and returns the keys dropped so they can be looked up again
+func (c *TTLCache) PurgeMisses() []string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var purged []string
+	for key, entry := range c.entries {
+		if entry.value == "" {
+			delete(c.entries, key)
+			purged = append(purged, key)
+		}
+	}
+	return purged
+}
+
+// Len returns the number of entries held (including not yet evicted
+// expired ones)
+func (c *TTLCache) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.entries)
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTTLCacheExpiry(t *testing.T) {
+	cache := NewTTLCache(10, 40*time.Millisecond, 10*time.Millisecond)
+	cache.Set("10.0.0.5", "corp.local")
+	cache.Set("10.0.0.6", "") // Failed lookup
+
+	if value, ok := cache.Get("10.0.0.5"); !ok || value != "corp.local" {
+		t.Fatalf("Get(found) = %q, %v; want corp.local, true", value, ok)
+	}
+	if value, ok := cache.Get("10.0.0.6"); !ok || value != "" {
+		t.Fatalf("Get(miss) = %q, %v; want cached empty value", value, ok)
+	}
+
+	// The miss TTL runs out first, so only the failed lookup is re-resolved
+	time.Sleep(20 * time.Millisecond)
+	if _, ok := cache.Get("10.0.0.6"); ok {
+		t.Error("failed lookup still cached after miss TTL")
+	}
+	if _, ok := cache.Get("10.0.0.5"); !ok {
+		t.Error("found value expired before its TTL")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if _, ok := cache.Get("10.0.0.5"); ok {
+		t.Error("found value still cached after TTL")
+	}
+	if cache.Len() != 0 {
+		t.Errorf("Len() = %d after expiry, want 0", cache.Len())
+	}
+}
+
+func TestTTLCacheEviction(t *testing.T) {
+	cache := NewTTLCache(2, time.Minute, time.Second)
+	cache.Set("a", "one")
+	cache.Set("b", "") // Expires first
+	cache.Set("c", "three")
+
+	if cache.Len() != 2 {
+		t.Fatalf("Len() = %d, want 2", cache.Len())
+	}
+	if _, ok := cache.Get("b"); ok {
+		t.Error("entry closest to expiring was not evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := cache.Get(key); !ok {
+			t.Errorf("%q evicted, want kept", key)
+		}
+	}
+}
+
+func TestTTLCachePurgeMisses(t *testing.T) {
+	cache := NewTTLCache(10, time.Minute, time.Minute)
+	cache.Set("found", "corp.local")
+	cache.Set("missed", "")
+
+	purged := cache.PurgeMisses()
+	if len(purged) != 1 || purged[0] != "missed" {
+		t.Fatalf("PurgeMisses() = %v, want [missed]", purged)
+	}
+	if _, ok := cache.Get("found"); !ok {
+		t.Error("PurgeMisses dropped a found value")
+	}
+}
@@ -0,0 +1,80 @@
+package config
+
+import "github.com/musyoka101/sliver-graphs/internal/models"
+
+// Privilege and type values for AgentFilter ("" matches any)
+const (
+	FilterPrivileged = "privileged"
+	FilterStandard   = "standard"
+	FilterSession    = "session"
+	FilterBeacon     = "beacon"
+)
+
+// AgentFilter is a compound quick filter on privilege level and agent type.
+// The zero value matches every agent.
+type AgentFilter struct {
+	Privilege string `json:"privilege,omitempty"` // "privileged", "standard" or "" (any)
+	Type      string `json:"type,omitempty"`      // "session", "beacon" or "" (any)
+}
+
+// AgentFilterPresets returns the filter combinations offered in the picker
+func AgentFilterPresets() []AgentFilter {
+	return []AgentFilter{
+		{},
+		{Privilege: FilterPrivileged},
+		{Privilege: FilterStandard},
+		{Type: FilterSession},
+		{Type: FilterBeacon},
+		{Privilege: FilterPrivileged, Type: FilterSession},
+		{Privilege: FilterPrivileged, Type: FilterBeacon},
+		{Privilege: FilterStandard, Type: FilterSession},
+		{Privilege: FilterStandard, Type: FilterBeacon},
+	}
+}
+
+// IsActive reports whether the filter hides anything
+func (f AgentFilter) IsActive() bool {
+	return f.Privilege != "" || f.Type != ""
+}
+
+// Matches reports whether agent passes the filter
+func (f AgentFilter) Matches(agent models.Agent) bool {
+	switch f.Privilege {
+	case FilterPrivileged:
+		if !agent.IsPrivileged {
+			return false
+		}
+	case FilterStandard:
+		if agent.IsPrivileged {
+			return false
+		}
+	}
+
+	switch f.Type {
+	case FilterSession:
+		return agent.IsSession
+	case FilterBeacon:
+		return !agent.IsSession
+	}
+	return true
+}
+
+// String describes the filter, e.g. "privileged sessions" or "all agents"
+func (f AgentFilter) String() string {
+	noun := "agents"
+	switch f.Type {
+	case FilterSession:
+		noun = "sessions"
+	case FilterBeacon:
+		noun = "beacons"
+	}
+
+	switch f.Privilege {
+	case FilterPrivileged, FilterStandard:
+		return f.Privilege + " " + noun
+	}
+	if noun == "agents" {
+		return "all agents"
+	}
+	return noun
+}
@@ -0,0 +1,417 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Prefs holds operator preferences persisted between runs
+type Prefs struct {
+	ExpandedSubnets []string `json:"expanded_subnets,omitempty"` // Subnets left expanded in topology views
+	WatchList       []string `json:"watch_list,omitempty"`       // Hostname glob patterns to watch (e.g. "DC*")
+
+	// Subnets left collapsed (only kept when SubnetDefault would expand them)
+	CollapsedSubnets []string `json:"collapsed_subnets,omitempty"`
+
+	// How subnets without a remembered state start: "collapsed", "expanded"
+	// or "privileged" (expanded if they hold a live privileged agent)
+	SubnetDefault string `json:"subnet_default,omitempty"`
+
+	// Where dead agents go in the Tree/Box views: "mixed", "bottom" or "top"
+	DeadPlacement string `json:"dead_placement,omitempty"`
+
+	// Which tasked beacons the Task Queue Monitor lists first: "pending"
+	// (most pending), "completion" (nearest done) or "checkin" (soonest
+	// next check-in); cycle with 'O'
+	TaskSort string `json:"task_sort,omitempty"`
+
+	// Smallest terminal the full layout is drawn in; below this a
+	// "terminal too small" message is shown instead
+	MinWidth  int `json:"min_width,omitempty"`
+	MinHeight int `json:"min_height,omitempty"`
+
+	// Activity Metrics sparkline rows in display order, e.g. ["beacons",
+	// "dead", "transport:dns"]. Available: sessions, beacons, total, new,
+	// privileged, dead, rate, transport:<mtls|http|dns|tcp|other>
+	SparklineMetrics []string `json:"sparkline_metrics,omitempty"`
+
+	// Longest hostname shown in the agent lines/boxes, Network Map subnet
+	// boxes, Network Topology panel and the Table's Host column before it's
+	// cut with "…"; 0 keeps each view's own default
+	HostnameMaxLength int `json:"hostname_max_length,omitempty"`
+
+	// Table view columns in display order, e.g. ["host", "user", "ip", "pid"].
+	// Available: id, type, userhost, user, host, os, arch, ip, transport,
+	// priv, pid, process, version, lastcheckin, uptime, statetime, privtime,
+	// domain, note
+	TableColumns []string `json:"table_columns,omitempty"`
+
+	// Missed intervals before a beacon counts as dead, keyed by transport
+	// substring (e.g. {"dns": 8}). Merged over the built-in defaults.
+	TransportTolerance map[string]float64 `json:"transport_tolerance,omitempty"`
+
+	// Usernames to reclassify regardless of the privilege keyword heuristic,
+	// e.g. {"NT AUTHORITY\\NETWORK SERVICE": false, "CORP\\svc_backup": true}.
+	// Keys match case-insensitively, as "DOMAIN\user" or a bare account name.
+	PrivilegeOverrides map[string]bool `json:"privilege_overrides,omitempty"`
+
+	// Free-text notes for the Table view's note column, keyed by hostname
+	// (case-insensitive), e.g. {"DC01": "primary DC, no noisy tooling"}
+	AgentNotes map[string]string `json:"agent_notes,omitempty"`
+
+	// Alert display time in seconds, keyed by alert type ("critical",
+	// "warning", "success", "info", "notice") or category (e.g.
+	// "beacon_task_queued"). Category entries win over type entries.
+	AlertTTLs map[string]float64 `json:"alert_ttls,omitempty"`
+
+	// Expected number of hosts in scope; progress toward it is shown in
+	// the header and Quick Stats panel (0 disables)
+	Baseline int `json:"baseline,omitempty"`
+
+	// Views left out of the 'v' cycle (e.g. ["dashboard"]); they stay
+	// reachable through their direct keybinds
+	CycleSkipViews []string `json:"cycle_skip_views,omitempty"`
+
+	// Show alert timestamps as "2m ago" instead of "15:04" (toggle with 'w')
+	RelativeAlertTimes bool `json:"relative_alert_times,omitempty"`
+
+	// Tint privileged agents with the theme's PrivilegedBg (toggle with 'P')
+	PrivilegedEmphasis bool `json:"privileged_emphasis,omitempty"`
+
+	// Tint every agent row/box with the theme's state backgrounds
+	// (SessionBg, BeaconBg, DeadBg, NewBg, PrivilegedBg); toggle with 'B'
+	AgentBackgrounds bool `json:"agent_backgrounds,omitempty"`
+
+	// Subnets listed in the dashboard's Network Topology panel before the
+	// rest collapse into "... and N more"; 0 fits as many as the terminal
+	// height allows
+	TopologySubnets int `json:"topology_subnets,omitempty"`
+
+	// Show the symbol legend under the Network Map (toggle with 'm')
+	MapLegend bool `json:"map_legend,omitempty"`
+
+	// Show a dimmed privilege badge on dead agents so a lost admin session
+	// stays recognizable (normally the Tree/Box views drop the badge and the
+	// Network Map shows it undimmed)
+	DeadPrivilegeBadge bool `json:"dead_privilege_badge,omitempty"`
+
+	// Hide non-critical chrome (help footer, header debug text, non-critical
+	// alerts) for unattended monitoring; toggle with 'z'
+	QuietMode bool `json:"quiet_mode,omitempty"`
+
+	// Debug fields in the header status line: "scroll" (Scroll %, the
+	// default), "all" (plus Term WxH) or "none"; cycle with 'V'
+	HeaderDebug string `json:"header_debug,omitempty"`
+
+	// Show "user" instead of "DOMAIN\user" in agent lines and the Table
+	// view; the details panel keeps the full name (toggle with 'u')
+	ShortUsernames bool `json:"short_usernames,omitempty"`
+
+	// Show fleet status in the terminal title, e.g. "Sliver: 12S 30B (2 crit)"
+	// (toggle with 'W'; off by default as some multiplexers mishandle it)
+	WindowTitle bool `json:"window_title,omitempty"`
+
+	// Hold agents missing OS, transport or address (usually mid-handshake)
+	// out of the views until the server fills them in
+	HideIncomplete bool `json:"hide_incomplete,omitempty"`
+
+	// Sparkline characters: "blocks" (default), "braille" or "ascii" for
+	// fonts/terminals that can't draw block elements
+	SparklineStyle string `json:"sparkline_style,omitempty"`
+
+	// Seconds without a successful refresh before the header's Last Update
+	// turns yellow (red at 4x) and shows the data's age; 0 disables
+	StaleAfter int `json:"stale_after"`
+
+	// Tempo gauge thresholds: events (task completions + new agents +
+	// alerts) in the last 10 minutes to read "active" and "hot"
+	TempoActive int `json:"tempo_active,omitempty"`
+	TempoHot    int `json:"tempo_hot,omitempty"`
+
+	// Seconds to wait when connecting to the server (default 10; values
+	// outside 0.5-300 use the default). SLIVER_TUI_CONNECT_TIMEOUT overrides.
+	ConnectTimeout float64 `json:"connect_timeout,omitempty"`
+
+	// Fraction the 5s refresh interval is randomly varied by, e.g. 0.1 for
+	// ±10%, so operators sharing a server don't poll in lockstep (0
+	// disables; capped at 0.5)
+	RefreshJitter float64 `json:"refresh_jitter"`
+
+	// Count unique hosts instead of agent connections in the footer, Quick
+	// Stats, tactical panel and network map totals (toggle with 'c')
+	CountHosts bool `json:"count_hosts,omitempty"`
+
+	// Alerts of one category allowed within 10s before further ones collapse
+	// into a single "N agents" summary (click it to list the hosts); 0 disables
+	AlertCoalesce int `json:"alert_coalesce"`
+
+	// Last few themes used, most recent first, as theme indices; the first
+	// is restored at startup and 'T' swaps back to the second
+	RecentThemes []int `json:"recent_themes,omitempty"`
+
+	// Last compound agent filter picked with 'F' (zero value shows all)
+	AgentFilter AgentFilter `json:"agent_filter,omitzero"`
+
+	path string // File the prefs were loaded from (and are saved to)
+
+	// Set when the file couldn't be parsed: Save leaves it alone so a
+	// hand-edit typo doesn't get overwritten with defaults
+	readOnly bool
+}
+
+// Dead agent placement modes for Prefs.DeadPlacement
+const (
+	DeadPlacementMixed  = "mixed"  // Dead agents stay interleaved with live ones
+	DeadPlacementBottom = "bottom" // Dead agents sorted after live ones
+	DeadPlacementTop    = "top"    // Dead agents sorted before live ones
+)
+
+// Subnet expansion defaults for Prefs.SubnetDefault
+const (
+	SubnetDefaultCollapsed  = "collapsed"  // Every subnet starts collapsed
+	SubnetDefaultExpanded   = "expanded"   // Every subnet starts expanded
+	SubnetDefaultPrivileged = "privileged" // Subnets with privileged agents start expanded
+)
+
+// NextDeadPlacement returns the placement after current in the toggle cycle
+func NextDeadPlacement(current string) string {
+	switch current {
+	case DeadPlacementMixed:
+		return DeadPlacementBottom
+	case DeadPlacementBottom:
+		return DeadPlacementTop
+	default:
+		return DeadPlacementMixed
+	}
+}
+
+// Header debug field sets for Prefs.HeaderDebug
+const (
+	HeaderDebugScroll = "scroll" // Scroll percentage only
+	HeaderDebugAll    = "all"    // Scroll percentage and terminal size
+	HeaderDebugNone   = "none"   // Neither
+)
+
+// NextHeaderDebug returns the header debug set after current in the toggle cycle
+func NextHeaderDebug(current string) string {
+	switch current {
+	case HeaderDebugAll:
+		return HeaderDebugNone
+	case HeaderDebugNone:
+		return HeaderDebugScroll
+	default:
+		return HeaderDebugAll
+	}
+}
+
+// Task Queue Monitor orders for Prefs.TaskSort
+const (
+	TaskSortPending    = "pending"    // Most pending tasks first
+	TaskSortCompletion = "completion" // Closest to finishing first
+	TaskSortCheckin    = "checkin"    // Soonest next check-in first
+)
+
+// NextTaskSort returns the task order after current in the toggle cycle
+func NextTaskSort(current string) string {
+	switch current {
+	case TaskSortCompletion:
+		return TaskSortCheckin
+	case TaskSortCheckin:
+		return TaskSortPending
+	default:
+		return TaskSortCompletion
+	}
+}
+
+// DefaultStaleAfter is the default Prefs.StaleAfter in seconds
+const DefaultStaleAfter = 30
+
+// Default and largest Prefs.RefreshJitter
+const (
+	DefaultRefreshJitter = 0.1
+	MaxRefreshJitter     = 0.5
+)
+
+// MaxRecentThemes is how many themes Prefs.RecentThemes remembers
+const MaxRecentThemes = 3
+
+// DefaultPrefs returns the preferences used when no prefs file exists
+func DefaultPrefs() *Prefs {
+	return &Prefs{
+		DeadPlacement: DeadPlacementMixed,
+		TaskSort:      TaskSortPending,
+		HeaderDebug:   HeaderDebugScroll,
+		MinWidth:      90,
+		MinHeight:     24,
+		StaleAfter:    DefaultStaleAfter,
+		TempoActive:   3,
+		TempoHot:      15,
+		RefreshJitter: DefaultRefreshJitter,
+		AlertCoalesce: 3,
+	}
+}
+
+// PrefsPath returns the location of the prefs file
+// (e.g. ~/.config/sliver-tui/prefs.json on Linux)
+func PrefsPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate config dir: %w", err)
+	}
+	return filepath.Join(dir, "sliver-tui", "prefs.json"), nil
+}
+
+// LoadPrefs reads the prefs file, falling back to defaults if it doesn't exist.
+// Fields missing from the file keep their default values.
+func LoadPrefs() (*Prefs, error) {
+	prefs := DefaultPrefs()
+
+	path, err := PrefsPath()
+	if err != nil {
+		return prefs, err
+	}
+	prefs.path = path
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return prefs, nil
+	}
+	if err != nil {
+		return prefs, fmt.Errorf("failed to read prefs: %w", err)
+	}
+
+	if err := json.Unmarshal(data, prefs); err != nil {
+		prefs = DefaultPrefs()
+		prefs.path = path
+		prefs.readOnly = true
+		return prefs, fmt.Errorf("failed to parse prefs %s (changes won't be saved until it's fixed): %w", path, err)
+	}
+	prefs.path = path
+
+	return prefs, nil
+}
+
+// Save writes the prefs back to disk (atomically via a temp file). Prefs
+// whose file failed to parse are never saved.
+func (p *Prefs) Save() error {
+	if p.readOnly {
+		return nil
+	}
+	if p.path == "" {
+		path, err := PrefsPath()
+		if err != nil {
+			return err
+		}
+		p.path = path
+	}
+
+	if err := os.MkdirAll(filepath.Dir(p.path), 0700); err != nil {
+		return fmt.Errorf("failed to create prefs dir: %w", err)
+	}
+
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode prefs: %w", err)
+	}
+
+	tmp := p.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write prefs: %w", err)
+	}
+	if err := os.Rename(tmp, p.path); err != nil {
+		return fmt.Errorf("failed to write prefs: %w", err)
+	}
+
+	return nil
+}
+
+// SetExpandedSubnets records the expanded subnets from a subnet -> expanded
+// map. Collapsed ones are recorded too unless subnets start collapsed anyway.
+func (p *Prefs) SetExpandedSubnets(expanded map[string]bool) {
+	p.ExpandedSubnets = p.ExpandedSubnets[:0]
+	p.CollapsedSubnets = p.CollapsedSubnets[:0]
+	keepCollapsed := p.SubnetDefault != "" && p.SubnetDefault != SubnetDefaultCollapsed
+	for subnet, isExpanded := range expanded {
+		if isExpanded {
+			p.ExpandedSubnets = append(p.ExpandedSubnets, subnet)
+		} else if keepCollapsed {
+			p.CollapsedSubnets = append(p.CollapsedSubnets, subnet)
+		}
+	}
+	sort.Strings(p.ExpandedSubnets)
+	sort.Strings(p.CollapsedSubnets)
+}
+
+// IsWatched reports whether hostname matches any watch list pattern.
+// Patterns use shell glob syntax and match case-insensitively.
+func (p *Prefs) IsWatched(hostname string) bool {
+	if hostname == "" {
+		return false
+	}
+	hostname = strings.ToLower(hostname)
+	for _, pattern := range p.WatchList {
+		matched, err := path.Match(strings.ToLower(pattern), hostname)
+		if err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// UseTheme moves the theme at index to the front of RecentThemes
+func (p *Prefs) UseTheme(index int) {
+	recent := []int{index}
+	for _, i := range p.RecentThemes {
+		if i != index && len(recent) < MaxRecentThemes {
+			recent = append(recent, i)
+		}
+	}
+	p.RecentThemes = recent
+}
+
+// ValidRecentThemes drops out-of-range and duplicate theme indices (e.g.
+// from a prefs file written by a build with more themes)
+func (p *Prefs) ValidRecentThemes() []int {
+	seen := make(map[int]bool)
+	var recent []int
+	for _, i := range p.RecentThemes {
+		if i >= 0 && i < GetThemeCount() && !seen[i] && len(recent) < MaxRecentThemes {
+			seen[i] = true
+			recent = append(recent, i)
+		}
+	}
+	p.RecentThemes = recent
+	return recent
+}
+
+// AgentNote returns the agent_notes entry for hostname, or "" if it has none
+func (p *Prefs) AgentNote(hostname string) string {
+	if note, ok := p.AgentNotes[hostname]; ok {
+		return note
+	}
+	for host, note := range p.AgentNotes {
+		if strings.EqualFold(strings.TrimSpace(host), hostname) {
+			return note
+		}
+	}
+	return ""
+}
+
+// ExpandedSubnetMap returns the persisted subnet states as a subnet ->
+// expanded map (subnets missing from it take the SubnetDefault)
+func (p *Prefs) ExpandedSubnetMap() map[string]bool {
+	expanded := make(map[string]bool, len(p.ExpandedSubnets)+len(p.CollapsedSubnets))
+	for _, subnet := range p.CollapsedSubnets {
+		expanded[subnet] = false
+	}
+	for _, subnet := range p.ExpandedSubnets {
+		expanded[subnet] = true
+	}
+	return expanded
+}
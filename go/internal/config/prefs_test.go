@@ -0,0 +1,60 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveKeepsUnparsablePrefs(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	path, err := PrefsPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		t.Fatal(err)
+	}
+	original := []byte(`{"watch_list": ["DC*"], "dead_placement": "top",}`) // Trailing comma
+	if err := os.WriteFile(path, original, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	prefs, err := LoadPrefs()
+	if err == nil {
+		t.Fatal("LoadPrefs accepted invalid JSON")
+	}
+	if prefs == nil || len(prefs.WatchList) != 0 {
+		t.Fatalf("LoadPrefs = %+v, want defaults", prefs)
+	}
+
+	prefs.WatchList = []string{"WS*"} // A toggle saves
+	if err := prefs.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(original) {
+		t.Errorf("prefs file overwritten with %s", got)
+	}
+}
+
+func TestSaveRoundTrip(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	prefs, err := LoadPrefs()
+	if err != nil {
+		t.Fatalf("LoadPrefs with no file: %v", err)
+	}
+	prefs.WatchList = []string{"DC*"}
+	if err := prefs.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	loaded, err := LoadPrefs()
+	if err != nil || len(loaded.WatchList) != 1 || loaded.WatchList[0] != "DC*" {
+		t.Errorf("reloaded WatchList = %v (err %v), want [DC*]", loaded.WatchList, err)
+	}
+}
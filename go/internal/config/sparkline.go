@@ -0,0 +1,22 @@
+package config
+
+// Sparkline character styles for Prefs.SparklineStyle
+const (
+	SparklineBlocks  = "blocks"  // Unicode block elements (default)
+	SparklineBraille = "braille" // Braille dot columns (for fonts without block elements)
+	SparklineASCII   = "ascii"   // Plain ASCII (non-Unicode terminals)
+)
+
+// SparklineRamp returns the characters for a sparkline style, lowest first:
+// index 0 is an empty (zero) cell, then 8 rising levels. Unknown styles get
+// the block ramp.
+func SparklineRamp(style string) []string {
+	switch style {
+	case SparklineBraille:
+		return []string{"⠀", "⡀", "⣀", "⣄", "⣤", "⣦", "⣶", "⣷", "⣿"}
+	case SparklineASCII:
+		return []string{" ", "_", ".", ":", "-", "=", "+", "*", "#"}
+	default:
+		return []string{"░", "▁", "▂", "▃", "▄", "▅", "▆", "▇", "█"}
+	}
+}
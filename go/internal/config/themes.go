@@ -28,9 +28,16 @@ type Theme struct {
 	ProtocolTCP     lipgloss.Color
 	ProtocolDefault lipgloss.Color
 	
+	// OS family colors (OS ribbon), distinct from the protocol colors
+	OSWindows       lipgloss.Color
+	OSLinux         lipgloss.Color
+	OSMacOS         lipgloss.Color
+	
 	// Badge colors
 	NewBadgeColor   lipgloss.Color
 	PrivBadgeColor  lipgloss.Color
+	WarningColor    lipgloss.Color // Warning badges (duplicate PID, old build, stalls)
+	WatchedColor    lipgloss.Color // Watch-listed host marker
 	
 	// Panel colors
 	TacticalBorder  lipgloss.Color
@@ -92,8 +99,13 @@ func defaultTheme() Theme {
 		ProtocolDNS:     lipgloss.Color("#8be9fd"),
 		ProtocolTCP:     lipgloss.Color("#8be9fd"),
 		ProtocolDefault: lipgloss.Color("#8be9fd"),
+		OSWindows:       lipgloss.Color("#bd93f9"),
+		OSLinux:         lipgloss.Color("#ffb86c"),
+		OSMacOS:         lipgloss.Color("#f8f8f2"),
 		NewBadgeColor:   lipgloss.Color("#f1fa8c"),
 		PrivBadgeColor:  lipgloss.Color("#ff79c6"),
+		WarningColor:    lipgloss.Color("#ffb86c"),
+		WatchedColor:    lipgloss.Color("#f1fa8c"),
 		TacticalBorder:  lipgloss.Color("#00d7ff"),
 		TacticalSection: lipgloss.Color("#f1fa8c"),
 		TacticalValue:   lipgloss.Color("#50fa7b"),
@@ -131,8 +143,13 @@ func rainbowTheme() Theme {
 		ProtocolDNS:     lipgloss.Color("#06ffa5"), // Green
 		ProtocolTCP:     lipgloss.Color("#ff006e"), // Pink
 		ProtocolDefault: lipgloss.Color("#ffbe0b"), // Yellow
+		OSWindows:       lipgloss.Color("#3a86ff"), // Royal blue
+		OSLinux:         lipgloss.Color("#fb5607"), // Red-orange
+		OSMacOS:         lipgloss.Color("#e0e0e0"), // Silver
 		NewBadgeColor:   lipgloss.Color("#ffff00"), // Bright yellow
 		PrivBadgeColor:  lipgloss.Color("#ffd700"),
+		WarningColor:    lipgloss.Color("#ff8800"), // Orange
+		WatchedColor:    lipgloss.Color("#ffd700"), // Gold
 		TacticalBorder:  lipgloss.Color("#ff00ff"),
 		TacticalSection: lipgloss.Color("#ff8800"),
 		TacticalValue:   lipgloss.Color("#00ff00"),
@@ -170,8 +187,13 @@ func cyberpunkTheme() Theme {
 		ProtocolDNS:     lipgloss.Color("#00b4d8"),
 		ProtocolTCP:     lipgloss.Color("#8338ec"),
 		ProtocolDefault: lipgloss.Color("#39ff14"),
+		OSWindows:       lipgloss.Color("#3a86ff"), // Blue
+		OSLinux:         lipgloss.Color("#ffbe0b"), // Amber
+		OSMacOS:         lipgloss.Color("#e0e0e0"), // Silver
 		NewBadgeColor:   lipgloss.Color("#ffff00"),
 		PrivBadgeColor:  lipgloss.Color("#ff006e"),
+		WarningColor:    lipgloss.Color("#ff9e00"), // Neon orange
+		WatchedColor:    lipgloss.Color("#ffbe0b"), // Amber
 		TacticalBorder:  lipgloss.Color("#39ff14"),
 		TacticalSection: lipgloss.Color("#ff006e"),
 		TacticalValue:   lipgloss.Color("#00f5ff"),
@@ -209,8 +231,13 @@ func matrixTheme() Theme {
 		ProtocolDNS:     lipgloss.Color("#adff2f"),
 		ProtocolTCP:     lipgloss.Color("#90ee90"),
 		ProtocolDefault: lipgloss.Color("#00ff41"),
+		OSWindows:       lipgloss.Color("#008f11"), // Dark green
+		OSLinux:         lipgloss.Color("#ccff90"), // Pale green
+		OSMacOS:         lipgloss.Color("#e0e0e0"), // Silver
 		NewBadgeColor:   lipgloss.Color("#76ff03"), // Lime green
 		PrivBadgeColor:  lipgloss.Color("#ffd700"),
+		WarningColor:    lipgloss.Color("#ffb000"), // Amber
+		WatchedColor:    lipgloss.Color("#ffd700"), // Gold
 		TacticalBorder:  lipgloss.Color("#00ff41"),
 		TacticalSection: lipgloss.Color("#ffd700"),
 		TacticalValue:   lipgloss.Color("#76ff03"),
@@ -248,8 +275,13 @@ func tacticalTheme() Theme {
 		ProtocolDNS:     lipgloss.Color("#ff9f1c"), // Orange
 		ProtocolTCP:     lipgloss.Color("#4a7c59"),
 		ProtocolDefault: lipgloss.Color("#457b9d"),
+		OSWindows:       lipgloss.Color("#1d9bf0"), // Blue
+		OSLinux:         lipgloss.Color("#e76f51"), // Burnt orange
+		OSMacOS:         lipgloss.Color("#d8dee2"), // Light gray
 		NewBadgeColor:   lipgloss.Color("#ffd60a"),
 		PrivBadgeColor:  lipgloss.Color("#ffb700"),
+		WarningColor:    lipgloss.Color("#f4a261"), // Sandy orange
+		WatchedColor:    lipgloss.Color("#ffd60a"), // Yellow
 		TacticalBorder:  lipgloss.Color("#ff6b35"),
 		TacticalSection: lipgloss.Color("#06d6a0"),
 		TacticalValue:   lipgloss.Color("#ffd60a"),
@@ -287,8 +319,13 @@ func pastelTheme() Theme {
 		ProtocolDNS:     lipgloss.Color("#b5e48c"),
 		ProtocolTCP:     lipgloss.Color("#ffb5a7"),
 		ProtocolDefault: lipgloss.Color("#90dbf4"),
+		OSWindows:       lipgloss.Color("#cdb4db"), // Lavender
+		OSLinux:         lipgloss.Color("#ffd6a5"), // Apricot
+		OSMacOS:         lipgloss.Color("#e5e5e5"), // Light gray
 		NewBadgeColor:   lipgloss.Color("#f4d58d"),
 		PrivBadgeColor:  lipgloss.Color("#ff99c8"),
+		WarningColor:    lipgloss.Color("#ffc09f"), // Pastel orange
+		WatchedColor:    lipgloss.Color("#f4d58d"), // Butter
 		TacticalBorder:  lipgloss.Color("#ff99c8"),
 		TacticalSection: lipgloss.Color("#a9def9"),
 		TacticalValue:   lipgloss.Color("#b5e48c"),
@@ -326,8 +363,13 @@ func heatmapTheme() Theme {
 		ProtocolDNS:     lipgloss.Color("#ffff00"),
 		ProtocolTCP:     lipgloss.Color("#0096ff"),
 		ProtocolDefault: lipgloss.Color("#888888"),
+		OSWindows:       lipgloss.Color("#b266ff"), // Violet
+		OSLinux:         lipgloss.Color("#00cc99"), // Green
+		OSMacOS:         lipgloss.Color("#dddddd"), // Light gray
 		NewBadgeColor:   lipgloss.Color("#ff0000"),
 		PrivBadgeColor:  lipgloss.Color("#ff0000"),
+		WarningColor:    lipgloss.Color("#ff8800"), // Orange
+		WatchedColor:    lipgloss.Color("#ffd700"), // Gold
 		TacticalBorder:  lipgloss.Color("#ff0000"),
 		TacticalSection: lipgloss.Color("#ff8800"),
 		TacticalValue:   lipgloss.Color("#ffff00"),
@@ -365,8 +407,13 @@ func lipGlossTheme() Theme {
 		ProtocolDNS:     lipgloss.Color("#2dd4bf"), // Teal
 		ProtocolTCP:     lipgloss.Color("#c084fc"), // Purple
 		ProtocolDefault: lipgloss.Color("#818cf8"), // Indigo
+		OSWindows:       lipgloss.Color("#fb923c"), // Orange
+		OSLinux:         lipgloss.Color("#facc15"), // Yellow
+		OSMacOS:         lipgloss.Color("#e5e7eb"), // Light gray
 		NewBadgeColor:   lipgloss.Color("#fbbf24"), // Amber yellow
 		PrivBadgeColor:  lipgloss.Color("#f9a8d4"), // Pink
+		WarningColor:    lipgloss.Color("#fb923c"), // Orange
+		WatchedColor:    lipgloss.Color("#fbbf24"), // Amber yellow
 		TacticalBorder:  lipgloss.Color("#d946ef"), // Fuchsia
 		TacticalSection: lipgloss.Color("#a78bfa"), // Violet
 		TacticalValue:   lipgloss.Color("#22d3ee"), // Cyan
@@ -417,8 +464,13 @@ func nordTheme() Theme {
 		ProtocolDNS:     lipgloss.Color("#81a1c1"), // Frost blue
 		ProtocolTCP:     lipgloss.Color("#b48ead"), // Aurora purple
 		ProtocolDefault: lipgloss.Color("#8fbcbb"), // Frost teal
+		OSWindows:       lipgloss.Color("#d08770"), // Aurora orange
+		OSLinux:         lipgloss.Color("#bf616a"), // Aurora red
+		OSMacOS:         lipgloss.Color("#e5e9f0"), // Snow storm
 		NewBadgeColor:   lipgloss.Color("#ebcb8b"), // Aurora yellow
 		PrivBadgeColor:  lipgloss.Color("#bf616a"), // Aurora red
+		WarningColor:    lipgloss.Color("#d08770"), // Aurora orange
+		WatchedColor:    lipgloss.Color("#ebcb8b"), // Aurora yellow
 		TacticalBorder:  lipgloss.Color("#88c0d0"), // Frost cyan
 		TacticalSection: lipgloss.Color("#81a1c1"), // Frost blue
 		TacticalValue:   lipgloss.Color("#a3be8c"), // Aurora green
@@ -456,8 +508,13 @@ func gruvboxTheme() Theme {
 		ProtocolDNS:     lipgloss.Color("#fabd2f"), // Bright yellow
 		ProtocolTCP:     lipgloss.Color("#fe8019"), // Bright orange
 		ProtocolDefault: lipgloss.Color("#83a598"), // Bright blue
+		OSWindows:       lipgloss.Color("#458588"), // Blue
+		OSLinux:         lipgloss.Color("#fb4934"), // Bright red
+		OSMacOS:         lipgloss.Color("#ebdbb2"), // Foreground
 		NewBadgeColor:   lipgloss.Color("#fabd2f"), // Bright yellow
 		PrivBadgeColor:  lipgloss.Color("#fb4934"), // Bright red
+		WarningColor:    lipgloss.Color("#fe8019"), // Bright orange
+		WatchedColor:    lipgloss.Color("#fabd2f"), // Bright yellow
 		TacticalBorder:  lipgloss.Color("#fe8019"), // Bright orange
 		TacticalSection: lipgloss.Color("#b8bb26"), // Bright green
 		TacticalValue:   lipgloss.Color("#fabd2f"), // Bright yellow
@@ -495,8 +552,13 @@ func tokyoNightTheme() Theme {
 		ProtocolDNS:     lipgloss.Color("#2ac3de"), // Teal
 		ProtocolTCP:     lipgloss.Color("#ff9e64"), // Orange
 		ProtocolDefault: lipgloss.Color("#7dcfff"), // Cyan
+		OSWindows:       lipgloss.Color("#3d59a1"), // Dark blue
+		OSLinux:         lipgloss.Color("#f7768e"), // Red
+		OSMacOS:         lipgloss.Color("#c0caf5"), // Foreground
 		NewBadgeColor:   lipgloss.Color("#e0af68"), // Yellow
 		PrivBadgeColor:  lipgloss.Color("#f7768e"), // Red
+		WarningColor:    lipgloss.Color("#ff9e64"), // Orange
+		WatchedColor:    lipgloss.Color("#e0af68"), // Yellow
 		TacticalBorder:  lipgloss.Color("#7aa2f7"), // Blue
 		TacticalSection: lipgloss.Color("#bb9af7"), // Purple
 		TacticalValue:   lipgloss.Color("#9ece6a"), // Green
@@ -534,8 +596,13 @@ func monokaiTheme() Theme {
 		ProtocolDNS:     lipgloss.Color("#a6e22e"), // Green
 		ProtocolTCP:     lipgloss.Color("#fd971f"), // Orange
 		ProtocolDefault: lipgloss.Color("#66d9ef"), // Cyan
+		OSWindows:       lipgloss.Color("#5f87ff"), // Blue
+		OSLinux:         lipgloss.Color("#f92672"), // Pink
+		OSMacOS:         lipgloss.Color("#f8f8f2"), // Foreground
 		NewBadgeColor:   lipgloss.Color("#e6db74"), // Yellow
 		PrivBadgeColor:  lipgloss.Color("#f92672"), // Pink/Red
+		WarningColor:    lipgloss.Color("#fd971f"), // Orange
+		WatchedColor:    lipgloss.Color("#e6db74"), // Yellow
 		TacticalBorder:  lipgloss.Color("#66d9ef"), // Cyan
 		TacticalSection: lipgloss.Color("#ae81ff"), // Purple
 		TacticalValue:   lipgloss.Color("#a6e22e"), // Green
@@ -573,8 +640,13 @@ func catppuccinMochaTheme() Theme {
 		ProtocolDNS:     lipgloss.Color("#94e2d5"), // Teal
 		ProtocolTCP:     lipgloss.Color("#fab387"), // Peach
 		ProtocolDefault: lipgloss.Color("#89dceb"), // Sky
+		OSWindows:       lipgloss.Color("#b4befe"), // Lavender
+		OSLinux:         lipgloss.Color("#f38ba8"), // Red
+		OSMacOS:         lipgloss.Color("#cdd6f4"), // Text
 		NewBadgeColor:   lipgloss.Color("#f9e2af"), // Yellow
 		PrivBadgeColor:  lipgloss.Color("#f38ba8"), // Red
+		WarningColor:    lipgloss.Color("#fab387"), // Peach
+		WatchedColor:    lipgloss.Color("#f9e2af"), // Yellow
 		TacticalBorder:  lipgloss.Color("#89b4fa"), // Blue
 		TacticalSection: lipgloss.Color("#cba6f7"), // Mauve
 		TacticalValue:   lipgloss.Color("#a6e3a1"), // Green
@@ -612,8 +684,13 @@ func catppuccinMacchiatoTheme() Theme {
 		ProtocolDNS:     lipgloss.Color("#8bd5ca"), // Teal
 		ProtocolTCP:     lipgloss.Color("#f5a97f"), // Peach
 		ProtocolDefault: lipgloss.Color("#91d7e3"), // Sky
+		OSWindows:       lipgloss.Color("#b7bdf8"), // Lavender
+		OSLinux:         lipgloss.Color("#ed8796"), // Red
+		OSMacOS:         lipgloss.Color("#cad3f5"), // Text
 		NewBadgeColor:   lipgloss.Color("#eed49f"), // Yellow
 		PrivBadgeColor:  lipgloss.Color("#ed8796"), // Red
+		WarningColor:    lipgloss.Color("#f5a97f"), // Peach
+		WatchedColor:    lipgloss.Color("#eed49f"), // Yellow
 		TacticalBorder:  lipgloss.Color("#8aadf4"), // Blue
 		TacticalSection: lipgloss.Color("#c6a0f6"), // Mauve
 		TacticalValue:   lipgloss.Color("#a6da95"), // Green
@@ -651,8 +728,13 @@ func catppuccinFrappeTheme() Theme {
 		ProtocolDNS:     lipgloss.Color("#81c8be"), // Teal
 		ProtocolTCP:     lipgloss.Color("#ef9f76"), // Peach
 		ProtocolDefault: lipgloss.Color("#99d1db"), // Sky
+		OSWindows:       lipgloss.Color("#babbf1"), // Lavender
+		OSLinux:         lipgloss.Color("#e78284"), // Red
+		OSMacOS:         lipgloss.Color("#c6d0f5"), // Text
 		NewBadgeColor:   lipgloss.Color("#e5c890"), // Yellow
 		PrivBadgeColor:  lipgloss.Color("#e78284"), // Red
+		WarningColor:    lipgloss.Color("#ef9f76"), // Peach
+		WatchedColor:    lipgloss.Color("#e5c890"), // Yellow
 		TacticalBorder:  lipgloss.Color("#8caaee"), // Blue
 		TacticalSection: lipgloss.Color("#ca9ee6"), // Mauve
 		TacticalValue:   lipgloss.Color("#a6d189"), // Green
@@ -690,8 +772,13 @@ func catppuccinLatteTheme() Theme {
 		ProtocolDNS:     lipgloss.Color("#179299"), // Teal
 		ProtocolTCP:     lipgloss.Color("#fe640b"), // Peach
 		ProtocolDefault: lipgloss.Color("#04a5e5"), // Sky
+		OSWindows:       lipgloss.Color("#7287fd"), // Lavender
+		OSLinux:         lipgloss.Color("#d20f39"), // Red
+		OSMacOS:         lipgloss.Color("#4c4f69"), // Text
 		NewBadgeColor:   lipgloss.Color("#df8e1d"), // Yellow
 		PrivBadgeColor:  lipgloss.Color("#d20f39"), // Red
+		WarningColor:    lipgloss.Color("#fe640b"), // Peach
+		WatchedColor:    lipgloss.Color("#df8e1d"), // Yellow
 		TacticalBorder:  lipgloss.Color("#1e66f5"), // Blue
 		TacticalSection: lipgloss.Color("#8839ef"), // Mauve
 		TacticalValue:   lipgloss.Color("#40a02b"), // Green
@@ -0,0 +1,13 @@
+package config
+
+import "testing"
+
+func TestThemesSetBadgeColors(t *testing.T) {
+	for i := 0; i < GetThemeCount(); i++ {
+		theme := GetTheme(i)
+		if theme.WarningColor == "" || theme.WatchedColor == "" {
+			t.Errorf("theme %q: WarningColor %q, WatchedColor %q; want both set",
+				theme.Name, theme.WarningColor, theme.WatchedColor)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package config
 
+import "strings"
+
 // View defines how agents are rendered
 type View struct {
 	Name string
@@ -31,3 +33,40 @@ func GetView(index int) View {
 func GetViewCount() int {
 	return 4
 }
+
+// NextViewIndex returns the index after current in the view cycle, skipping
+// views whose names appear in skip (case-insensitive, spaces/dashes/underscores
+// ignored, so "network_map" matches "Network Map"). Skipped views stay
+// reachable through their direct keybinds. If every view is skipped the
+// cycle falls back to the plain next index.
+func NextViewIndex(current int, skip []string) int {
+	count := GetViewCount()
+	skipped := make(map[string]bool, len(skip))
+	for _, name := range skip {
+		skipped[normalizeViewName(name)] = true
+	}
+	
+	for step := 1; step <= count; step++ {
+		next := ((current+step)%count + count) % count
+		if !skipped[normalizeViewName(GetView(next).Name)] {
+			return next
+		}
+	}
+	return ((current+1)%count + count) % count
+}
+
+// ViewIndexByName returns the index of the view called name (matched like
+// NextViewIndex's skip names, so "network_map" finds "Network Map")
+func ViewIndexByName(name string) (int, bool) {
+	for i := 0; i < GetViewCount(); i++ {
+		if normalizeViewName(GetView(i).Name) == normalizeViewName(name) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// normalizeViewName lowercases a view name and strips separators
+func normalizeViewName(name string) string {
+	return strings.NewReplacer(" ", "", "-", "", "_", "").Replace(strings.ToLower(name))
+}
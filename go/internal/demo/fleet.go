@@ -0,0 +1,224 @@
+// Package demo generates a synthetic Sliver fleet so every view and panel
+// can be exercised without a live server (demos, screenshots, UI work).
+package demo
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bishopfox/sliver/protobuf/clientpb"
+)
+
+// implant is one synthetic agent; protobufs are built from it on each Next
+type implant struct {
+	id, hostname, username, os, arch string
+	transport, remoteAddress, c2     string
+	filename, version, proxyURL      string
+	pid                              int32
+	session                          bool
+	interval                         time.Duration // Beacons only
+	lastCheckin                      time.Time
+	silent                           bool // Beacon stopped checking in (goes dead)
+	tasks, completed                 int64
+}
+
+// host is a template for a synthetic compromised machine
+type host struct {
+	hostname, os, arch, subnet, user, adminUser string
+}
+
+// hosts are the machines the synthetic fleet lands on
+var hosts = []host{
+	{"DC01", "windows", "amd64", "10.10.1", "CORP\\jsmith", "NT AUTHORITY\\SYSTEM"},
+	{"DC02", "windows", "amd64", "10.10.1", "CORP\\svc_backup", "CORP\\Administrator"},
+	{"FS01", "windows", "amd64", "10.10.1", "CORP\\mjones", "NT AUTHORITY\\SYSTEM"},
+	{"SQL01", "windows", "amd64", "10.10.2", "CORP\\svc_sql", "NT AUTHORITY\\SYSTEM"},
+	{"WEB01", "linux", "amd64", "10.10.2", "www-data", "root"},
+	{"WEB02", "linux", "amd64", "10.10.2", "nginx", "root"},
+	{"WS-ALICE", "windows", "amd64", "192.168.56", "CORP\\alice", "CORP\\Administrator"},
+	{"WS-BOB", "windows", "386", "192.168.56", "CORP\\bob", "NT AUTHORITY\\SYSTEM"},
+	{"MBP-CFO", "darwin", "arm64", "192.168.56", "cfo", "root"},
+	{"JUMP01", "linux", "amd64", "172.16.5", "ubuntu", "root"},
+	{"BUILD01", "linux", "arm64", "172.16.5", "jenkins", "root"},
+	{"VPN-GW", "linux", "amd64", "203.0.113", "admin", "root"},
+}
+
+// transports are the synthetic C2 channels with their listener URLs
+var transports = []struct{ name, c2 string }{
+	{"mtls", "mtls://c2.example.com:8888"},
+	{"http", "https://cdn.example.com/assets/v2/static/js/app.bundle.min.js"},
+	{"dns", "dns://ns1.example.com"},
+	{"wg", "wg://10.66.0.1:53"},
+}
+
+// maxImplants caps how large the fleet grows
+const maxImplants = 40
+
+// Fleet is an evolving synthetic fleet. Each Next call advances it one
+// step: beacons check in, some go quiet and die (and sometimes come back),
+// tasks get queued and completed, sessions close and new agents arrive.
+type Fleet struct {
+	rng      *rand.Rand
+	implants []*implant
+	mutex    sync.Mutex
+}
+
+// NewFleet creates a fleet seeded with a fixed, varied starting set
+// (Windows/Linux/macOS, pivots, privileged users, every transport, and a
+// couple of dead beacons)
+func NewFleet() *Fleet {
+	f := &Fleet{rng: rand.New(rand.NewSource(1337))}
+	now := time.Now()
+	for i, h := range hosts {
+		a := f.newImplant(h, i%3 == 0)
+		a.lastCheckin = now.Add(-time.Duration(f.rng.Intn(20)) * time.Second)
+		f.implants = append(f.implants, a)
+	}
+
+	// A pivot chain: JUMP01 reaches SQL01 which reaches DC02
+	jump, sql := f.implants[9], f.implants[3]
+	sql.proxyURL = "socks5://" + jump.id
+	f.implants[1].proxyURL = "socks5://" + sql.id
+
+	// Two beacons that died a while ago
+	for _, a := range f.implants[len(f.implants)-2:] {
+		a.session = false
+		a.interval = 30 * time.Second
+		a.silent = true
+		a.lastCheckin = now.Add(-10 * time.Minute)
+	}
+	return f
+}
+
+// newImplant creates an implant on h, privileged or not
+func (f *Fleet) newImplant(h host, privileged bool) *implant {
+	t := transports[f.rng.Intn(len(transports))]
+	user := h.user
+	if privileged {
+		user = h.adminUser
+	}
+	filename := "/tmp/.cache/update"
+	if h.os == "windows" {
+		filename = `C:\Windows\Temp\svchost.exe`
+	}
+	a := &implant{
+		id:            f.newID(),
+		hostname:      h.hostname,
+		username:      user,
+		os:            h.os,
+		arch:          h.arch,
+		transport:     t.name,
+		remoteAddress: fmt.Sprintf("%s.%d:%d", h.subnet, 10+f.rng.Intn(200), 40000+f.rng.Intn(20000)),
+		c2:            t.c2,
+		filename:      filename,
+		version:       "1.5.42",
+		pid:           int32(1000 + f.rng.Intn(9000)),
+		session:       f.rng.Intn(3) == 0,
+		lastCheckin:   time.Now(),
+	}
+	if f.rng.Intn(8) == 0 {
+		a.version = "1.5.39" // A straggler for version skew
+	}
+	if !a.session {
+		a.interval = []time.Duration{10 * time.Second, 30 * time.Second, time.Minute}[f.rng.Intn(3)]
+	}
+	return a
+}
+
+// newID returns a random UUID-shaped ID
+func (f *Fleet) newID() string {
+	b := make([]byte, 16)
+	f.rng.Read(b)
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// Next advances the fleet one step and returns it as server protobufs
+func (f *Fleet) Next() ([]*clientpb.Session, []*clientpb.Beacon) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.step(time.Now())
+
+	var sessions []*clientpb.Session
+	var beacons []*clientpb.Beacon
+	for _, a := range f.implants {
+		if a.session {
+			sessions = append(sessions, &clientpb.Session{
+				ID: a.id, Name: strings.ToLower(a.hostname), Hostname: a.hostname,
+				Username: a.username, OS: a.os, Arch: a.arch, Transport: a.transport,
+				RemoteAddress: a.remoteAddress, PID: a.pid, Filename: a.filename,
+				LastCheckin: a.lastCheckin.Unix(), ActiveC2: a.c2, Version: a.version,
+				ProxyURL: a.proxyURL,
+			})
+			continue
+		}
+		beacons = append(beacons, &clientpb.Beacon{
+			ID: a.id, Name: strings.ToLower(a.hostname), Hostname: a.hostname,
+			Username: a.username, OS: a.os, Arch: a.arch, Transport: a.transport,
+			RemoteAddress: a.remoteAddress, PID: a.pid, Filename: a.filename,
+			LastCheckin: a.lastCheckin.Unix(), ActiveC2: a.c2, Version: a.version,
+			ProxyURL: a.proxyURL, Interval: int64(a.interval), Jitter: int64(a.interval / 10),
+			NextCheckin: a.lastCheckin.Add(a.interval).Unix(),
+			TasksCount:  a.tasks, TasksCountCompleted: a.completed,
+		})
+	}
+	return sessions, beacons
+}
+
+// step applies one round of random events
+func (f *Fleet) step(now time.Time) {
+	for _, a := range f.implants {
+		if a.session {
+			a.lastCheckin = now
+			continue
+		}
+		switch {
+		case a.silent && f.rng.Intn(40) == 0:
+			a.silent = false // Comes back from the dead
+		case !a.silent && f.rng.Intn(60) == 0:
+			a.silent = true // Stops checking in; dead after a few intervals
+		}
+		if !a.silent && now.Sub(a.lastCheckin) >= a.interval {
+			a.lastCheckin = now
+			if a.completed < a.tasks && f.rng.Intn(2) == 0 {
+				a.completed++
+			}
+		}
+		if !a.silent && f.rng.Intn(15) == 0 {
+			a.tasks++
+		}
+	}
+
+	// Occasionally a session closes
+	if f.rng.Intn(30) == 0 {
+		for i, a := range f.implants {
+			if a.session && a.proxyURL == "" && !f.isParent(a.id) {
+				f.implants = append(f.implants[:i], f.implants[i+1:]...)
+				break
+			}
+		}
+	}
+
+	// New agents arrive now and then, sometimes through a pivot
+	if len(f.implants) < maxImplants && f.rng.Intn(8) == 0 {
+		a := f.newImplant(hosts[f.rng.Intn(len(hosts))], f.rng.Intn(4) == 0)
+		if f.rng.Intn(4) == 0 {
+			parent := f.implants[f.rng.Intn(len(f.implants))]
+			a.proxyURL = "socks5://" + parent.id
+		}
+		f.implants = append(f.implants, a)
+	}
+}
+
+// isParent reports whether any implant pivots through id
+func (f *Fleet) isParent(id string) bool {
+	for _, a := range f.implants {
+		if strings.Contains(a.proxyURL, id) {
+			return true
+		}
+	}
+	return false
+}
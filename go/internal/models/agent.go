@@ -4,21 +4,26 @@ import "time"
 
 // Agent represents a Sliver agent
 type Agent struct {
-	ID            string
-	Hostname      string
-	Username      string
-	OS            string
-	Transport     string
-	RemoteAddress string
-	IsSession     bool
-	IsPrivileged  bool
-	IsDead        bool
-	IsNew         bool      // Newly discovered (< 5 min)
-	FirstSeen     time.Time // When first discovered
-	ProxyURL      string    // Non-empty if pivoted through another agent
-	ParentID      string    // ID of parent agent (if pivoted)
-	Children      []Agent   // Child agents (pivoted through this one)
-	Domain        string    // DNS domain name (e.g., "m3c.local") - queried from agent
+	ID             string
+	Hostname       string
+	Username       string
+	OS             string
+	Transport      string
+	RemoteAddress  string
+	IsSession      bool
+	IsPrivileged   bool
+	IsDead         bool
+	IsNew          bool      // Newly discovered (< 5 min)
+	Incomplete     bool      // OS, transport or address missing (e.g. mid-handshake)
+	FirstSeen      time.Time // When first discovered
+	PrivilegeSince time.Time // When IsPrivileged last changed (FirstSeen if never)
+	TypeSince      time.Time // When session/beacon type last changed
+	StatusSince    time.Time // When alive/dead last changed
+	ProxyURL       string    // Non-empty if pivoted through another agent
+	ParentID       string    // ID of parent agent (if pivoted)
+	Children       []Agent   // Child agents (pivoted through this one)
+	PivotCycle     bool      // Parent chain loops back on itself (shown at root level)
+	Domain         string    // DNS domain name (e.g., "m3c.local") - queried from agent
 
 	// Additional fields from protobuf
 	PID            int32  // Process ID
@@ -34,6 +39,10 @@ type Agent struct {
 	LastCheckin    int64  // Last check-in time (unix timestamp)
 	Evasion        bool   // Evasion mode enabled
 	Burned         bool   // Marked as compromised
+
+	// Estimated bytes on the wire per hour from check-ins (beacons only;
+	// derived from interval and jitter since Sliver doesn't report traffic)
+	TrafficPerHour int64
 }
 
 // Stats holds statistics
@@ -42,4 +51,5 @@ type Stats struct {
 	Beacons     int
 	Hosts       int
 	Compromised int
+	Privileged  int
 }
@@ -1,6 +1,7 @@
 package tracking
 
 import (
+	"strings"
 	"sync"
 	"time"
 
@@ -12,8 +13,32 @@ type ActivitySample struct {
 	Timestamp       time.Time
 	SessionsCount   int
 	BeaconsCount    int
-	NewCount        int
+	NewCount        int // Agents still flagged NEW (seen < 5 min ago)
+	Arrivals        int // Agents first seen since the previous sample
 	PrivilegedCount int
+	DeadCount       int
+	TransportCounts map[string]int // Live agents per TransportBucket
+}
+
+// Transports lists the TransportBucket names in display order
+var Transports = []string{"mtls", "http", "dns", "tcp", "other"}
+
+// TransportBucket groups a transport string (e.g. "https", "mtls") into one
+// of Transports
+func TransportBucket(transport string) string {
+	transportLower := strings.ToLower(transport)
+	switch {
+	case strings.Contains(transportLower, "mtls"):
+		return "mtls"
+	case strings.Contains(transportLower, "http"):
+		return "http"
+	case strings.Contains(transportLower, "dns"):
+		return "dns"
+	case strings.Contains(transportLower, "tcp"):
+		return "tcp"
+	default:
+		return "other"
+	}
 }
 
 // ActivityTracker tracks activity over time (12-hour rolling window)
@@ -36,7 +61,7 @@ func NewActivityTracker() *ActivityTracker {
 }
 
 // AddSample adds a new activity sample (rolling window)
-func (at *ActivityTracker) AddSample(sessions, beacons, newAgents, privileged int) {
+func (at *ActivityTracker) AddSample(sessions, beacons, newAgents, arrivals, privileged, dead int, transportCounts map[string]int) {
 	at.mutex.Lock()
 	defer at.mutex.Unlock()
 
@@ -45,7 +70,10 @@ func (at *ActivityTracker) AddSample(sessions, beacons, newAgents, privileged in
 		SessionsCount:   sessions,
 		BeaconsCount:    beacons,
 		NewCount:        newAgents,
+		Arrivals:        arrivals,
 		PrivilegedCount: privileged,
+		DeadCount:       dead,
+		TransportCounts: transportCounts,
 	}
 
 	at.Samples = append(at.Samples, sample)
@@ -71,21 +99,56 @@ func (at *ActivityTracker) GetSessionDuration() time.Duration {
 	return time.Since(at.StartTime)
 }
 
-// SampleCurrentActivity samples the current agent state
+// SampleCurrentActivity samples the current agent state. Arrivals count
+// agents first seen after the previous sample, so each agent is counted
+// once however many samples it stays NEW for; the first sample has none
+// (the fleet was already there).
 func (at *ActivityTracker) SampleCurrentActivity(agents []models.Agent, stats models.Stats) {
+	at.mutex.RLock()
+	var lastSample time.Time
+	if len(at.Samples) > 0 {
+		lastSample = at.Samples[len(at.Samples)-1].Timestamp
+	}
+	at.mutex.RUnlock()
+
 	// Count metrics from current agents
 	newCount := 0
+	arrivals := 0
 	privilegedCount := 0
+	deadCount := 0
+	transportCounts := make(map[string]int)
 
 	for _, agent := range agents {
+		if agent.IsDead {
+			deadCount++
+		} else {
+			transportCounts[TransportBucket(agent.Transport)]++
+		}
 		if agent.IsNew {
 			newCount++
 		}
+		if !lastSample.IsZero() && agent.FirstSeen.After(lastSample) {
+			arrivals++
+		}
 		if agent.IsPrivileged {
 			privilegedCount++
 		}
 	}
 
 	// Add sample to tracker
-	at.AddSample(stats.Sessions, stats.Beacons, newCount, privilegedCount)
+	at.AddSample(stats.Sessions, stats.Beacons, newCount, arrivals, privilegedCount, deadCount, transportCounts)
+}
+
+// RecentNewAgents returns the most NEW agents seen in any sample taken
+// within window (0 if there are no recent samples)
+func (at *ActivityTracker) RecentNewAgents(window time.Duration) int {
+	at.mutex.RLock()
+	defer at.mutex.RUnlock()
+
+	cutoff := time.Now().Add(-window)
+	most := 0
+	for i := len(at.Samples) - 1; i >= 0 && at.Samples[i].Timestamp.After(cutoff); i-- {
+		most = max(most, at.Samples[i].NewCount)
+	}
+	return most
 }
@@ -7,19 +7,58 @@ import (
 	"github.com/musyoka101/sliver-graphs/internal/models"
 )
 
-// Global tracking for agent changes
-var (
-	agentTracker     = make(map[string]time.Time) // ID -> first seen time
-	trackerMutex     sync.RWMutex
-	newAgentTimeout  = 5 * time.Minute // Mark as NEW if seen < 5 minutes ago
-	lostAgents       = make(map[string]models.Agent)
-	lostAgentTimeout = 5 * time.Minute
-)
+// Tracker remembers when agents were first seen and which ones recently
+// disappeared. Each server connection gets its own Tracker so state never
+// leaks between servers.
+type Tracker struct {
+	agentTracker     map[string]time.Time  // ID -> first seen time
+	states           map[string]agentState // ID -> last known state + when each part changed
+	lostAgents       map[string]models.Agent
+	newAgentTimeout  time.Duration // Mark as NEW if seen < 5 minutes ago
+	lostAgentTimeout time.Duration
+	mutex            sync.RWMutex
+}
+
+// agentState is the state tracked for "time in current state" dwell times
+type agentState struct {
+	last                                   models.Agent // Previous observation
+	privilegeSince, typeSince, statusSince time.Time
+}
+
+// StateChange reports which dwell-tracked attributes of an agent changed
+// between two observations
+type StateChange struct {
+	Privilege bool // Privileged ↔ standard
+	Type      bool // Session ↔ beacon
+	Status    bool // Alive ↔ dead
+}
+
+// CompareState returns which tracked attributes differ from old to current.
+// The tracker's dwell timers and the UI's transition alerts both use it, so
+// they agree on what counts as a state change.
+func CompareState(old, current models.Agent) StateChange {
+	return StateChange{
+		Privilege: old.IsPrivileged != current.IsPrivileged,
+		Type:      old.IsSession != current.IsSession,
+		Status:    old.IsDead != current.IsDead,
+	}
+}
+
+// NewTracker creates an empty tracker with the default timeouts
+func NewTracker() *Tracker {
+	return &Tracker{
+		agentTracker:     make(map[string]time.Time),
+		states:           make(map[string]agentState),
+		lostAgents:       make(map[string]models.Agent),
+		newAgentTimeout:  5 * time.Minute,
+		lostAgentTimeout: 5 * time.Minute,
+	}
+}
 
 // TrackAgentChanges updates the tracking maps for new/lost agents
-func TrackAgentChanges(agents []models.Agent) []models.Agent {
-	trackerMutex.Lock()
-	defer trackerMutex.Unlock()
+func (t *Tracker) TrackAgentChanges(agents []models.Agent) []models.Agent {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
 
 	now := time.Now()
 	currentAgentIDs := make(map[string]bool)
@@ -30,24 +69,50 @@ func TrackAgentChanges(agents []models.Agent) []models.Agent {
 		currentAgentIDs[agentID] = true
 
 		// Check if this is a new agent
-		if firstSeen, exists := agentTracker[agentID]; exists {
+		if firstSeen, exists := t.agentTracker[agentID]; exists {
 			agents[i].FirstSeen = firstSeen
-			agents[i].IsNew = now.Sub(firstSeen) < newAgentTimeout
+			agents[i].IsNew = now.Sub(firstSeen) < t.newAgentTimeout
 		} else {
 			// First time seeing this agent
-			agentTracker[agentID] = now
+			t.agentTracker[agentID] = now
 			agents[i].FirstSeen = now
 			agents[i].IsNew = true
 		}
+
+		// Reset the dwell timer of whichever attribute changed
+		state, exists := t.states[agentID]
+		if !exists {
+			state = agentState{
+				last:           agents[i],
+				privilegeSince: agents[i].FirstSeen,
+				typeSince:      agents[i].FirstSeen,
+				statusSince:    agents[i].FirstSeen,
+			}
+		}
+		change := CompareState(state.last, agents[i])
+		if change.Privilege {
+			state.privilegeSince = now
+		}
+		if change.Type {
+			state.typeSince = now
+		}
+		if change.Status {
+			state.statusSince = now
+		}
+		state.last = agents[i]
+		t.states[agentID] = state
+		agents[i].PrivilegeSince = state.privilegeSince
+		agents[i].TypeSince = state.typeSince
+		agents[i].StatusSince = state.statusSince
 	}
 
 	// Find lost agents (previously tracked but not in current list)
-	for agentID, firstSeen := range agentTracker {
+	for agentID, firstSeen := range t.agentTracker {
 		if !currentAgentIDs[agentID] {
 			// This agent is missing, add to lost agents if not already there
-			if _, exists := lostAgents[agentID]; !exists {
+			if _, exists := t.lostAgents[agentID]; !exists {
 				// Create a lost agent entry (we don't have full data)
-				lostAgents[agentID] = models.Agent{
+				t.lostAgents[agentID] = models.Agent{
 					ID:        agentID,
 					FirstSeen: firstSeen,
 					IsDead:    true,
@@ -57,10 +122,11 @@ func TrackAgentChanges(agents []models.Agent) []models.Agent {
 	}
 
 	// Clean up old lost agents
-	for agentID, agent := range lostAgents {
-		if now.Sub(agent.FirstSeen) > lostAgentTimeout {
-			delete(lostAgents, agentID)
-			delete(agentTracker, agentID)
+	for agentID, agent := range t.lostAgents {
+		if now.Sub(agent.FirstSeen) > t.lostAgentTimeout {
+			delete(t.lostAgents, agentID)
+			delete(t.agentTracker, agentID)
+			delete(t.states, agentID)
 		}
 	}
 
@@ -68,13 +134,13 @@ func TrackAgentChanges(agents []models.Agent) []models.Agent {
 }
 
 // GetLostAgentsCount returns the number of recently lost agents
-func GetLostAgentsCount() int {
-	trackerMutex.RLock()
-	defer trackerMutex.RUnlock()
-	return len(lostAgents)
+func (t *Tracker) GetLostAgentsCount() int {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return len(t.lostAgents)
 }
 
 // GetLostAgentTimeout returns the timeout duration for lost agents
-func GetLostAgentTimeout() time.Duration {
-	return lostAgentTimeout
+func (t *Tracker) GetLostAgentTimeout() time.Duration {
+	return t.lostAgentTimeout
 }
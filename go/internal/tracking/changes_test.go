@@ -0,0 +1,69 @@
+package tracking
+
+import (
+	"testing"
+	"time"
+
+	"github.com/musyoka101/sliver-graphs/internal/models"
+)
+
+func TestTrackersDoNotShareState(t *testing.T) {
+	first, second := NewTracker(), NewTracker()
+
+	first.TrackAgentChanges([]models.Agent{{ID: "a1"}, {ID: "a2"}})
+	first.TrackAgentChanges([]models.Agent{{ID: "a2"}}) // a1 lost
+	if got := first.GetLostAgentsCount(); got != 1 {
+		t.Fatalf("first tracker lost count = %d, want 1", got)
+	}
+	if got := second.GetLostAgentsCount(); got != 0 {
+		t.Errorf("second tracker lost count = %d, want 0 (state leaked)", got)
+	}
+
+	// a2 is known to the first tracker only; the second sees it for the first time
+	time.Sleep(5 * time.Millisecond)
+	agents := second.TrackAgentChanges([]models.Agent{{ID: "a2"}})
+	known := first.TrackAgentChanges([]models.Agent{{ID: "a2"}})
+	if !agents[0].FirstSeen.After(known[0].FirstSeen) {
+		t.Errorf("second tracker FirstSeen %v not after first tracker's %v", agents[0].FirstSeen, known[0].FirstSeen)
+	}
+
+	// Dropping agents from the second tracker leaves the first untouched
+	second.TrackAgentChanges(nil)
+	if got := second.GetLostAgentsCount(); got != 1 {
+		t.Errorf("second tracker lost count = %d, want 1", got)
+	}
+	if got := first.GetLostAgentsCount(); got != 1 {
+		t.Errorf("first tracker lost count = %d after second tracker changed, want 1", got)
+	}
+}
+
+func TestDwellTimersResetOnChange(t *testing.T) {
+	tracker := NewTracker()
+	first := tracker.TrackAgentChanges([]models.Agent{{ID: "a1"}})[0]
+
+	time.Sleep(5 * time.Millisecond)
+	escalated := tracker.TrackAgentChanges([]models.Agent{{ID: "a1", IsPrivileged: true}})[0]
+	if !escalated.PrivilegeSince.After(first.PrivilegeSince) {
+		t.Errorf("PrivilegeSince %v not reset after escalation (was %v)", escalated.PrivilegeSince, first.PrivilegeSince)
+	}
+	if !escalated.TypeSince.Equal(first.TypeSince) || !escalated.StatusSince.Equal(first.StatusSince) {
+		t.Error("escalation reset the type or status dwell timer")
+	}
+
+	// An unchanged refresh keeps every timer
+	again := tracker.TrackAgentChanges([]models.Agent{{ID: "a1", IsPrivileged: true}})[0]
+	if !again.PrivilegeSince.Equal(escalated.PrivilegeSince) {
+		t.Error("unchanged refresh reset PrivilegeSince")
+	}
+}
+
+func TestCompareState(t *testing.T) {
+	old := models.Agent{IsSession: true}
+	change := CompareState(old, models.Agent{IsDead: true})
+	if change != (StateChange{Type: true, Status: true}) {
+		t.Errorf("CompareState = %+v, want Type and Status", change)
+	}
+	if change := CompareState(old, old); change != (StateChange{}) {
+		t.Errorf("CompareState of identical agents = %+v, want none", change)
+	}
+}
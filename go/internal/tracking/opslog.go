@@ -0,0 +1,112 @@
+package tracking
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OpsEventKind is the type of task lifecycle transition
+type OpsEventKind int
+
+const (
+	OpsTaskQueued    OpsEventKind = iota // Beacon TasksCount went up
+	OpsTaskCompleted                     // Beacon TasksCompleted went up
+)
+
+// String returns the log label for the event kind
+func (k OpsEventKind) String() string {
+	switch k {
+	case OpsTaskQueued:
+		return "TASK QUEUED"
+	case OpsTaskCompleted:
+		return "TASK COMPLETE"
+	default:
+		return "EVENT"
+	}
+}
+
+// OpsEvent is a single task lifecycle entry in the operations log
+type OpsEvent struct {
+	Timestamp      time.Time
+	Kind           OpsEventKind
+	AgentID        string
+	AgentName      string
+	TasksCount     int64 // Total tasks after the transition
+	TasksCompleted int64 // Completed tasks after the transition
+}
+
+// String formats the event as a tab-separated log line
+func (e OpsEvent) String() string {
+	return fmt.Sprintf("%s\t%s\t%s\t%s\t%d/%d done",
+		e.Timestamp.Format("2006-01-02 15:04:05"),
+		e.Kind,
+		e.AgentName,
+		e.AgentID,
+		e.TasksCompleted,
+		e.TasksCount)
+}
+
+// OpsLog is a bounded, durable timeline of task lifecycle events.
+// Unlike alerts it doesn't expire; the oldest entries are dropped once
+// MaxEvents is reached.
+type OpsLog struct {
+	Events    []OpsEvent
+	MaxEvents int
+	mutex     sync.RWMutex
+}
+
+// NewOpsLog creates an operations log holding at most maxEvents entries
+func NewOpsLog(maxEvents int) *OpsLog {
+	return &OpsLog{
+		Events:    []OpsEvent{},
+		MaxEvents: maxEvents,
+	}
+}
+
+// Add records a task lifecycle event (rolling window)
+func (l *OpsLog) Add(kind OpsEventKind, agentID, agentName string, tasksCount, tasksCompleted int64) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.Events = append(l.Events, OpsEvent{
+		Timestamp:      time.Now(),
+		Kind:           kind,
+		AgentID:        agentID,
+		AgentName:      agentName,
+		TasksCount:     tasksCount,
+		TasksCompleted: tasksCompleted,
+	})
+
+	// Keep only last MaxEvents
+	if len(l.Events) > l.MaxEvents {
+		l.Events = l.Events[len(l.Events)-l.MaxEvents:]
+	}
+}
+
+// GetEvents returns a copy of all events, oldest first (thread-safe)
+func (l *OpsLog) GetEvents() []OpsEvent {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	eventsCopy := make([]OpsEvent, len(l.Events))
+	copy(eventsCopy, l.Events)
+	return eventsCopy
+}
+
+// Export writes the log to path as tab-separated lines, oldest first
+func (l *OpsLog) Export(path string) error {
+	var sb strings.Builder
+	sb.WriteString("time\tevent\tagent\tid\ttasks\n")
+	for _, event := range l.GetEvents() {
+		sb.WriteString(event.String())
+		sb.WriteString("\n")
+	}
+
+	if err := os.WriteFile(path, []byte(sb.String()), 0600); err != nil {
+		return fmt.Errorf("failed to export ops log: %w", err)
+	}
+	return nil
+}
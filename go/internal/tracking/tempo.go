@@ -0,0 +1,94 @@
+package tracking
+
+import (
+	"sync"
+	"time"
+)
+
+// TempoLevel is the coarse "how busy is the engagement right now" reading
+type TempoLevel int
+
+const (
+	TempoCalm   TempoLevel = iota // Little or nothing happening
+	TempoActive                   // Steady activity
+	TempoHot                      // Bursts of tasks, agents and alerts
+)
+
+// String returns the display name of the level
+func (l TempoLevel) String() string {
+	switch l {
+	case TempoActive:
+		return "active"
+	case TempoHot:
+		return "hot"
+	default:
+		return "calm"
+	}
+}
+
+// TempoDelta is the activity observed between two refreshes
+type TempoDelta struct {
+	TasksCompleted int // Beacon tasks that finished
+	Alerts         int // Alerts raised by the refresh
+}
+
+// tempoEntry is a TempoDelta stamped with when it was recorded
+type tempoEntry struct {
+	at    time.Time
+	delta TempoDelta
+}
+
+// TempoTracker keeps a rolling window of per-refresh activity deltas
+type TempoTracker struct {
+	Window  time.Duration // How far back deltas count toward the rate
+	entries []tempoEntry
+	mutex   sync.RWMutex
+}
+
+// DefaultTempoWindow is the rolling window used by NewTempoTracker
+const DefaultTempoWindow = 10 * time.Minute
+
+// NewTempoTracker creates an empty tracker with the default window
+func NewTempoTracker() *TempoTracker {
+	return &TempoTracker{Window: DefaultTempoWindow}
+}
+
+// Record adds one refresh's deltas, dropping entries older than the window.
+// Empty deltas aren't stored.
+func (t *TempoTracker) Record(delta TempoDelta) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	now := time.Now()
+	t.prune(now)
+	if delta == (TempoDelta{}) {
+		return
+	}
+	t.entries = append(t.entries, tempoEntry{at: now, delta: delta})
+}
+
+// Totals sums the deltas recorded within the window
+func (t *TempoTracker) Totals() TempoDelta {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	cutoff := time.Now().Add(-t.Window)
+	var total TempoDelta
+	for _, entry := range t.entries {
+		if entry.at.After(cutoff) {
+			total.TasksCompleted += entry.delta.TasksCompleted
+			total.Alerts += entry.delta.Alerts
+		}
+	}
+	return total
+}
+
+// prune drops entries that have left the window (caller holds the lock)
+func (t *TempoTracker) prune(now time.Time) {
+	cutoff := now.Add(-t.Window)
+	keep := 0
+	for keep < len(t.entries) && !t.entries[keep].at.After(cutoff) {
+		keep++
+	}
+	t.entries = t.entries[keep:]
+}
@@ -37,8 +37,24 @@ func BuildAgentTree(agents []models.Agent) []models.Agent {
 	}
 
 	// Build tree structure using index (O(n) instead of O(n²))
+	visited := make(map[string]bool, len(agents))
 	for i := range rootAgents {
-		rootAgents[i].Children = buildChildrenFromIndex(rootAgents[i].ID, parentChildIndex)
+		visited[rootAgents[i].ID] = true
+		rootAgents[i].Children = buildChildrenFromIndex(rootAgents[i].ID, parentChildIndex, visited)
+	}
+
+	// Agents in a pivot cycle (A's parent is B and B's is A, or an agent is
+	// its own parent) are unreachable from any root; promote one per cycle
+	// to root level, flagged, so they still get drawn
+	for i := range agents {
+		if visited[agents[i].ID] || !inPivotCycle(agents[i].ID, agentMap) {
+			continue
+		}
+		cycleRoot := agents[i]
+		cycleRoot.PivotCycle = true
+		visited[cycleRoot.ID] = true
+		cycleRoot.Children = buildChildrenFromIndex(cycleRoot.ID, parentChildIndex, visited)
+		rootAgents = append(rootAgents, cycleRoot)
 	}
 
 	// If no root agents found, return all agents as roots
@@ -62,19 +78,40 @@ func extractParentID(proxyURL string, agentMap map[string]*models.Agent) string
 	return ""
 }
 
+// inPivotCycle reports whether following id's parent chain leads back to id
+func inPivotCycle(id string, agentMap map[string]*models.Agent) bool {
+	seen := make(map[string]bool)
+	for current := agentMap[id]; current != nil && current.ParentID != ""; current = agentMap[current.ParentID] {
+		if current.ParentID == id {
+			return true
+		}
+		if seen[current.ParentID] {
+			return false // Loops, but not through id
+		}
+		seen[current.ParentID] = true
+	}
+	return false
+}
+
 // buildChildrenFromIndex recursively builds children using pre-built index
-// This is O(n) total across all calls instead of O(n²)
-func buildChildrenFromIndex(parentID string, parentChildIndex map[string][]models.Agent) []models.Agent {
+// This is O(n) total across all calls instead of O(n²). Agents already in
+// visited are skipped, so malformed (cyclic) parent links can't recurse
+// forever.
+func buildChildrenFromIndex(parentID string, parentChildIndex map[string][]models.Agent, visited map[string]bool) []models.Agent {
 	children, exists := parentChildIndex[parentID]
 	if !exists {
 		return nil
 	}
 	
 	// Process each child and recursively get their children
-	result := make([]models.Agent, len(children))
-	for i, child := range children {
-		result[i] = child
-		result[i].Children = buildChildrenFromIndex(child.ID, parentChildIndex)
+	result := make([]models.Agent, 0, len(children))
+	for _, child := range children {
+		if visited[child.ID] {
+			continue
+		}
+		visited[child.ID] = true
+		child.Children = buildChildrenFromIndex(child.ID, parentChildIndex, visited)
+		result = append(result, child)
 	}
 	
 	return result
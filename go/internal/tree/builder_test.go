@@ -0,0 +1,100 @@
+package tree
+
+import (
+	"testing"
+	"time"
+
+	"github.com/musyoka101/sliver-graphs/internal/models"
+)
+
+// buildWithTimeout runs BuildAgentTree, failing the test if it doesn't return
+func buildWithTimeout(t *testing.T, agents []models.Agent) []models.Agent {
+	t.Helper()
+	done := make(chan []models.Agent, 1)
+	go func() { done <- BuildAgentTree(agents) }()
+	select {
+	case roots := <-done:
+		return roots
+	case <-time.After(2 * time.Second):
+		t.Fatal("BuildAgentTree did not return (pivot cycle not broken)")
+		return nil
+	}
+}
+
+// countNodes returns how many times each agent ID appears in the tree
+func countNodes(agents []models.Agent, counts map[string]int) {
+	for _, agent := range agents {
+		counts[agent.ID]++
+		countNodes(agent.Children, counts)
+	}
+}
+
+func TestBuildAgentTreePivotCycles(t *testing.T) {
+	tests := []struct {
+		name   string
+		agents []models.Agent
+		cycle  int // Roots expected to carry the PivotCycle flag
+	}{
+		{
+			name: "self parent",
+			agents: []models.Agent{
+				{ID: "root-1111", Hostname: "DC01"},
+				{ID: "self-2222", Hostname: "LOOP", ProxyURL: "socks5://self-2222"},
+			},
+			cycle: 1,
+		},
+		{
+			name: "two-agent cycle",
+			agents: []models.Agent{
+				{ID: "root-1111", Hostname: "DC01"},
+				{ID: "cyca-3333", Hostname: "A", ProxyURL: "tcp://cycb-4444"},
+				{ID: "cycb-4444", Hostname: "B", ProxyURL: "tcp://cyca-3333"},
+			},
+			cycle: 1,
+		},
+		{
+			name: "cycle with a hanging child, no clean roots",
+			agents: []models.Agent{
+				{ID: "cyca-3333", Hostname: "A", ProxyURL: "tcp://cycc-5555"},
+				{ID: "cycb-4444", Hostname: "B", ProxyURL: "tcp://cyca-3333"},
+				{ID: "cycc-5555", Hostname: "C", ProxyURL: "tcp://cycb-4444"},
+				{ID: "leaf-6666", Hostname: "D", ProxyURL: "tcp://cycb-4444"},
+			},
+			cycle: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roots := buildWithTimeout(t, tt.agents)
+
+			flagged := 0
+			for _, root := range roots {
+				if root.PivotCycle {
+					flagged++
+				}
+			}
+			if flagged != tt.cycle {
+				t.Errorf("%d roots flagged PivotCycle, want %d", flagged, tt.cycle)
+			}
+
+			// Every agent is drawn exactly once
+			counts := make(map[string]int)
+			countNodes(roots, counts)
+			for _, agent := range tt.agents {
+				if counts[agent.ID] != 1 {
+					t.Errorf("agent %s appears %d times in the tree, want 1", agent.ID, counts[agent.ID])
+				}
+			}
+		})
+	}
+}
+
+func TestBuildAgentTreeNoCycle(t *testing.T) {
+	roots := buildWithTimeout(t, []models.Agent{
+		{ID: "root-1111", Hostname: "DC01"},
+		{ID: "kid1-2222", Hostname: "WS01", ProxyURL: "tcp://root-1111"},
+	})
+	if len(roots) != 1 || len(roots[0].Children) != 1 || roots[0].PivotCycle {
+		t.Fatalf("BuildAgentTree = %+v, want one unflagged root with one child", roots)
+	}
+}
@@ -2,20 +2,32 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
+	"encoding/base64"
+	"hash/fnv"
+	"math/rand"
+	"net"
+	"net/netip"
 	"os"
+	"path/filepath"
+	"reflect"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	
 	"github.com/musyoka101/sliver-graphs/internal/alerts"
 	"github.com/musyoka101/sliver-graphs/internal/client"
 	"github.com/musyoka101/sliver-graphs/internal/config"
+	"github.com/musyoka101/sliver-graphs/internal/demo"
 	"github.com/musyoka101/sliver-graphs/internal/models"
 	"github.com/musyoka101/sliver-graphs/internal/tracking"
 	"github.com/musyoka101/sliver-graphs/internal/tree"
@@ -28,14 +40,25 @@ type ActivityTracker = tracking.ActivityTracker
 // NewActivityTracker is provided by tracking package
 var NewActivityTracker = tracking.NewActivityTracker
 
+// minForcedSampleGap is the least time between a change-driven activity
+// sample and the previous sample, so bursts of changes can't crowd the
+// timer samples out of the rolling window
+const minForcedSampleGap = 30 * time.Second
+
 // sampleCurrentActivity samples the current agent state and adds to tracker
 func (m *model) sampleCurrentActivity() {
+	m.sampleActivity(m.allAgents, m.allStats)
+}
+
+// sampleActivity adds a sample of the given agent state to the tracker
+func (m *model) sampleActivity(agents []Agent, stats Stats) {
 	if m.activityTracker == nil {
 		return
 	}
 	
 	// Use the tracking package's SampleCurrentActivity method
-	m.activityTracker.SampleCurrentActivity(m.agents, m.stats)
+	m.activityTracker.SampleCurrentActivity(agents, stats)
+	m.lastSampleAt = time.Now()
 }
 
 // extractFilename extracts just the filename from a full path (cross-platform)
@@ -45,26 +68,74 @@ func extractFilename(fullPath string) string {
 		return ""
 	}
 	
-	// Check if this is a Windows path (contains backslash)
-	if strings.Contains(fullPath, "\\") {
-		// Windows path - split on backslash
-		parts := strings.Split(fullPath, "\\")
-		if len(parts) > 0 {
-			return parts[len(parts)-1]
-		}
-	} else {
-		// Unix path - split on forward slash
-		parts := strings.Split(fullPath, "/")
-		if len(parts) > 0 {
-			return parts[len(parts)-1]
-		}
+	// Argv[0] may be quoted or padded (e.g. "\"C:\\Program Files\\app.exe\"")
+	path := strings.Trim(strings.TrimSpace(fullPath), "\"'")
+	
+	// Cut after the last separator of either kind so mixed paths
+	// like "C:\\Windows/Temp\\svchost.exe" are handled too
+	if idx := strings.LastIndexAny(path, "\\/"); idx != -1 {
+		path = path[idx+1:]
 	}
 	
 	// Fallback: return the full path if we couldn't parse it
-	return fullPath
+	if path == "" {
+		return fullPath
+	}
+	return path
+}
+
+// truncateText cuts s to at most width terminal cells, appending "…" when cut.
+// Unlike byte slicing it never splits a multibyte rune and counts wide
+// (CJK/emoji) characters as two cells.
+func truncateText(s string, width int) string {
+	return ansi.Truncate(s, width, "…")
+}
+
+// truncateURLMiddle shortens a URL to at most width cells by eliding the
+// middle of its path, keeping the scheme and host ("mtls://c2.example.com…/x").
+// URLs whose scheme+host alone don't fit are cut at the end instead.
+func truncateURLMiddle(url string, width int) string {
+	if ansi.StringWidth(url) <= width {
+		return url
+	}
+	
+	head, rest := url, ""
+	hostStart := 0
+	if idx := strings.Index(url, "://"); idx != -1 {
+		hostStart = idx + len("://")
+	}
+	if idx := strings.Index(url[hostStart:], "/"); idx != -1 {
+		head, rest = url[:hostStart+idx], url[hostStart+idx:]
+	}
+	
+	avail := width - ansi.StringWidth(head) - 1 // -1 for "…"
+	if rest == "" || avail < 1 {
+		return truncateText(url, width)
+	}
+	tail := []rune(rest)
+	if len(tail) > avail {
+		tail = tail[len(tail)-avail:]
+	}
+	return head + "…" + string(tail)
+}
+
+// padText right-pads s with spaces to width terminal cells
+// (fmt's %-Ns pads by bytes, which misaligns non-ASCII text)
+func padText(s string, width int) string {
+	if w := ansi.StringWidth(s); w < width {
+		return s + strings.Repeat(" ", width-w)
+	}
+	return s
 }
 
-// extractSubnet extracts subnet from IP address (e.g., "192.168.1.100" -> "192.168.1.0/24")
+// unknownSubnet is the single bucket for agents without a usable RemoteAddress
+const unknownSubnet = "Unknown"
+
+// noAddressPlaceholder is shown in place of a missing RemoteAddress
+const noAddressPlaceholder = "(no address)"
+
+// extractSubnet extracts subnet from IP address (e.g., "192.168.1.100" -> "192.168.1.0/24").
+// Missing or unparsable addresses go to unknownSubnet so those agents are never dropped.
 func extractSubnet(remoteAddress string) string {
 	// Extract IP from RemoteAddress (format: "ip:port")
 	ip := remoteAddress
@@ -77,7 +148,36 @@ func extractSubnet(remoteAddress string) string {
 	if len(octets) >= 3 {
 		return fmt.Sprintf("%s.%s.%s.0/24", octets[0], octets[1], octets[2])
 	}
-	return ""
+	return unknownSubnet
+}
+
+// displayAddress returns the agent's RemoteAddress, or a placeholder if it has none
+func displayAddress(remoteAddress string) string {
+	if remoteAddress == "" {
+		return noAddressPlaceholder
+	}
+	return remoteAddress
+}
+
+// isSameImplant reports whether two agents look like the same implant process
+// on the same host: matching hostname plus matching PID (or, if either PID is
+// unknown, matching remote IP). Used to follow a beacon across its promotion
+// to a session, which Sliver registers under a new ID.
+func isSameImplant(a, b Agent) bool {
+	if !strings.EqualFold(a.Hostname, b.Hostname) {
+		return false
+	}
+	if a.PID != 0 && b.PID != 0 {
+		return a.PID == b.PID
+	}
+	ipA, ipB := a.RemoteAddress, b.RemoteAddress
+	if idx := strings.Index(ipA, ":"); idx != -1 {
+		ipA = ipA[:idx]
+	}
+	if idx := strings.Index(ipB, ":"); idx != -1 {
+		ipB = ipB[:idx]
+	}
+	return ipA != "" && ipA == ipB
 }
 
 // updateSubnetOrder updates the list of subnets from active agents
@@ -89,10 +189,7 @@ func (m *model) updateSubnetOrder() {
 			continue
 		}
 		
-		subnet := extractSubnet(agent.RemoteAddress)
-		if subnet != "" {
-			subnetMap[subnet] = true
-		}
+		subnetMap[extractSubnet(agent.RemoteAddress)] = true
 	}
 	
 	// Convert map to ordered slice
@@ -103,6 +200,521 @@ func (m *model) updateSubnetOrder() {
 	
 	// Sort alphabetically for consistent ordering
 	sort.Strings(m.subnetOrder)
+	
+	// New subnets start in the configured default state
+	for _, subnet := range m.subnetOrder {
+		if _, exists := m.expandedSubnets[subnet]; !exists {
+			m.expandedSubnets[subnet] = m.defaultSubnetExpanded(subnet)
+		}
+	}
+}
+
+// defaultSubnetExpanded reports whether a subnet with no remembered state
+// starts expanded, per the subnet_default pref
+func (m model) defaultSubnetExpanded(subnet string) bool {
+	if m.prefs == nil {
+		return false
+	}
+	switch m.prefs.SubnetDefault {
+	case config.SubnetDefaultExpanded:
+		return true
+	case config.SubnetDefaultPrivileged:
+		for _, agent := range m.agents {
+			if agent.IsPrivileged && !agent.IsDead && extractSubnet(agent.RemoteAddress) == subnet {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// renderSubnetHeatStrip renders one colored block per subnet (in subnetOrder,
+// so block N is subnet #N for the number shortcuts): healthy, some dead, or
+// mostly dead. Blocks that don't fit in maxWidth collapse into "+N".
+func (m model) renderSubnetHeatStrip(maxWidth int) string {
+	if len(m.subnetOrder) == 0 {
+		return ""
+	}
+	
+	// Live/dead counts per subnet
+	live := make(map[string]int)
+	dead := make(map[string]int)
+	for _, agent := range m.agents {
+		subnet := extractSubnet(agent.RemoteAddress)
+		if agent.IsDead {
+			dead[subnet]++
+		} else {
+			live[subnet]++
+		}
+	}
+	
+	label := "Subnets "
+	available := maxWidth - len(label)
+	shown := len(m.subnetOrder)
+	overflow := ""
+	if shown > available {
+		// Reserve room for the "+N" suffix
+		shown = available - 4
+		if shown < 1 {
+			return ""
+		}
+		overflow = fmt.Sprintf(" +%d", len(m.subnetOrder)-shown)
+	}
+	
+	var blocks strings.Builder
+	for _, subnet := range m.subnetOrder[:shown] {
+		color := m.theme.SessionColor // Healthy
+		total := live[subnet] + dead[subnet]
+		if dead[subnet]*2 > total {
+			color = m.theme.DeadColor // Mostly dead
+		} else if dead[subnet] > 0 {
+			color = m.theme.BeaconColor // Some dead
+		}
+		blocks.WriteString(lipgloss.NewStyle().Foreground(color).Render("■"))
+	}
+	
+	mutedStyle := lipgloss.NewStyle().Foreground(m.theme.TacticalMuted)
+	return mutedStyle.Render(label) + blocks.String() + mutedStyle.Render(overflow)
+}
+
+// toggleAllSubnets expands every subnet unless all of them are already
+// expanded, in which case it collapses them all. The map is authoritative:
+// a subnet missing from it counts as collapsed.
+func toggleAllSubnets(expanded map[string]bool, subnets []string) {
+	allExpanded := len(subnets) > 0
+	for _, subnet := range subnets {
+		if !expanded[subnet] {
+			allExpanded = false
+			break
+		}
+	}
+	
+	for _, subnet := range subnets {
+		expanded[subnet] = !allExpanded
+	}
+}
+
+// focusSubnet expands target and collapses every other subnet (including
+// ones no longer in subnets, so stale expansions don't linger in the prefs)
+func focusSubnet(expanded map[string]bool, subnets []string, target string) {
+	for subnet := range expanded {
+		expanded[subnet] = false
+	}
+	for _, subnet := range subnets {
+		expanded[subnet] = false
+	}
+	expanded[target] = true
+}
+
+// isWatched reports whether the agent's hostname is on the operator's watch list
+func (m model) isWatched(agent Agent) bool {
+	return m.prefs != nil && m.prefs.IsWatched(agent.Hostname)
+}
+
+// watchBadge returns the watch list badge for an agent (empty if not watched)
+func (m model) watchBadge(agent Agent) string {
+	if !m.isWatched(agent) {
+		return ""
+	}
+	return " " + lipgloss.NewStyle().
+		Foreground(m.theme.WatchedColor).
+		Bold(true).
+		Render("⚑")
+}
+
+// ackDuration is how long an acknowledged agent stays silenced
+const ackDuration = 15 * time.Minute
+
+// isAcked reports whether alerts for an agent are currently silenced.
+// It is read-only (render code calls it); pruneExpiredAcks drops old entries.
+func (m model) isAcked(agentID string) bool {
+	until, ok := m.ackedAgents[agentID]
+	return ok && time.Now().Before(until)
+}
+
+// pruneExpiredAcks forgets acknowledgements whose silence has run out
+func (m *model) pruneExpiredAcks() {
+	now := time.Now()
+	for id, until := range m.ackedAgents {
+		if !now.Before(until) {
+			delete(m.ackedAgents, id)
+		}
+	}
+}
+
+// ackBadge returns the acknowledged badge for an agent (empty if not ack'd)
+func (m model) ackBadge(agent Agent) string {
+	if !m.isAcked(agent.ID) {
+		return ""
+	}
+	return " " + lipgloss.NewStyle().
+		Foreground(m.theme.TacticalMuted).
+		Italic(true).
+		Render("ack'd")
+}
+
+// isQuiet reports whether quiet mode (minimal chrome) is on
+func (m model) isQuiet() bool {
+	return m.prefs != nil && m.prefs.QuietMode
+}
+
+// chromeHeight returns the lines reserved around the viewport.
+// Header: title(1) + status(1) + empty(1) = 3 lines.
+// Footer: border(1) + stats(1) + border(1) + help(1) + empty(1) + slack(2) = 7 lines;
+// quiet mode drops the help line and its spacing. Footer banners add their
+// line plus a spacer each.
+func (m model) chromeHeight() int {
+	height := 10
+	if m.isQuiet() {
+		height = 8
+	}
+	if m.showLegend {
+		height++ // Transport legend line
+	}
+	if m.firstBloodHost != "" {
+		height += 2 // First blood banner
+	}
+	if m.searchMode || m.searchQuery != "" {
+		height += 2 // Search prompt / match counter
+	}
+	if m.pendingServer != "" {
+		height += 2 // Server switch confirmation
+	}
+	return height
+}
+
+// fitViewport resizes the viewport to the space left by chromeHeight; call
+// it whenever a footer banner or line appears or goes away
+func (m *model) fitViewport() {
+	if m.ready {
+		m.viewport.Height = max(m.termHeight-m.chromeHeight(), 1)
+	}
+}
+
+// transportColor returns the theme color for a tracking.TransportBucket name
+func (m model) transportColor(bucket string) lipgloss.Color {
+	switch bucket {
+	case "mtls":
+		return m.theme.ProtocolMTLS
+	case "http":
+		return m.theme.ProtocolHTTP
+	case "dns":
+		return m.theme.ProtocolDNS
+	case "tcp":
+		return m.theme.ProtocolTCP
+	default:
+		return m.theme.ProtocolDefault
+	}
+}
+
+// renderTransportLegend maps each transport to its current theme color
+// (plus dead agents, which override the transport color)
+func (m model) renderTransportLegend() string {
+	var entries []string
+	for _, transport := range tracking.Transports {
+		entries = append(entries, lipgloss.NewStyle().Foreground(m.transportColor(transport)).
+			Render("■ "+strings.ToUpper(transport)))
+	}
+	entries = append(entries, lipgloss.NewStyle().Foreground(m.theme.DeadColor).Render("■ DEAD"))
+	return lipgloss.NewStyle().Foreground(m.theme.HelpColor).Render("Transports: ") +
+		strings.Join(entries, "  ")
+}
+
+// agentBackground returns the background tint for an agent's row/box, if any.
+// With state backgrounds on, the most important state wins:
+// dead > privileged > new > session/beacon.
+func (m model) agentBackground(agent Agent) (lipgloss.Color, bool) {
+	if m.prefs == nil {
+		return "", false
+	}
+	if m.prefs.AgentBackgrounds {
+		switch {
+		case agent.IsDead:
+			return m.theme.DeadBg, true
+		case agent.IsPrivileged:
+			return m.theme.PrivilegedBg, true
+		case agent.IsNew:
+			return m.theme.NewBg, true
+		case agent.IsSession:
+			return m.theme.SessionBg, true
+		default:
+			return m.theme.BeaconBg, true
+		}
+	}
+	if m.prefs.PrivilegedEmphasis && agent.IsPrivileged && !agent.IsDead {
+		return m.theme.PrivilegedBg, true
+	}
+	return "", false
+}
+
+// applyBackground tints every line of s with bg. Styled segments end in an
+// SGR reset that would clear an outer background, so the background is
+// re-applied after each reset and closed at the end of every line (no bleed
+// into the next line).
+func applyBackground(s string, bg lipgloss.Color) string {
+	sample := lipgloss.NewStyle().Background(bg).Render("x")
+	bgSeq := sample[:strings.Index(sample, "x")]
+	if bgSeq == "" {
+		return s // No color support
+	}
+	
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = bgSeq + strings.ReplaceAll(line, "\x1b[0m", "\x1b[0m"+bgSeq) + "\x1b[0m"
+	}
+	return strings.Join(lines, "\n")
+}
+
+// isSlowTransport reports whether a beacon's transport gets extra dead-check
+// slack (e.g. DNS), so the UI can explain its long check-in gaps
+func (m model) isSlowTransport(agent Agent) bool {
+	return !agent.IsSession && m.clientOpts.ToleranceFor(agent.Transport) > client.DefaultDeadTolerance
+}
+
+// slowTransportBadge returns the " 🐢" marker for a live beacon on a slow
+// transport, so long gaps aren't mistaken for a dying beacon (empty otherwise)
+func (m model) slowTransportBadge(agent Agent) string {
+	if !m.isSlowTransport(agent) || agent.IsDead {
+		return ""
+	}
+	return " 🐢"
+}
+
+// saveSubnetPrefs persists which subnets are expanded so they survive restarts
+func (m *model) saveSubnetPrefs() {
+	if m.prefs == nil {
+		return
+	}
+	m.prefs.SetExpandedSubnets(m.expandedSubnets)
+	m.savePrefs()
+}
+
+// savePrefs writes prefs to disk, surfacing failures as a notice alert
+func (m *model) savePrefs() {
+	if err := m.prefs.Save(); err != nil {
+		m.alertManager.AddAlertWithDetails(
+			alerts.AlertNotice,
+			alerts.CategorySystemNotice,
+			"Failed to save preferences",
+			"prefs",
+			"",
+			err.Error(),
+		)
+	}
+}
+
+// sharedEgressBadge marks hosts whose IP other hosts also connect from
+const sharedEgressBadge = "⇄"
+
+// Agent and subnet glyphs (emoji icon style), shared by the views and the
+// Network Map legend so the two can't drift apart
+const (
+	glyphSession     = "◆"
+	glyphBeacon      = "◇"
+	glyphDead        = "💀"
+	glyphDeadCount   = "⚠️" // Dead agents in a subnet box summary
+	glyphPrivileged  = "💎"
+	glyphProxied     = "🔗"
+	glyphWatched     = "⚑"
+)
+
+// privilegeBadge returns the " 💎" suffix for a privileged agent. Dead agents
+// get none, or a dimmed one with the dead_privilege_badge pref so a lost
+// admin session is still recognizable.
+func (m model) privilegeBadge(agent Agent) string {
+	if !agent.IsPrivileged {
+		return ""
+	}
+	if !agent.IsDead {
+		return " " + glyphPrivileged
+	}
+	if m.prefs != nil && m.prefs.DeadPrivilegeBadge {
+		return " " + lipgloss.NewStyle().Foreground(m.theme.DeadColor).Faint(true).Render(glyphPrivileged)
+	}
+	return ""
+}
+
+// hostnameWidth returns the hostname_max_length pref, or def if unset
+func (m model) hostnameWidth(def int) int {
+	if m.prefs != nil && m.prefs.HostnameMaxLength > 0 {
+		return m.prefs.HostnameMaxLength
+	}
+	return def
+}
+
+// displayHostname cuts hostname to hostnameWidth(def) cells (by grapheme,
+// so wide and multi-byte names cut cleanly); def 0 means no limit
+func (m model) displayHostname(hostname string, def int) string {
+	if width := m.hostnameWidth(def); width > 0 {
+		return truncateText(hostname, width)
+	}
+	return hostname
+}
+
+// agentIP returns the IP part of a RemoteAddress ("ip:port", "[v6]:port")
+func agentIP(remoteAddress string) string {
+	if host, _, err := net.SplitHostPort(remoteAddress); err == nil {
+		return host
+	}
+	return remoteAddress
+}
+
+// findSharedEgress returns the IPs that agents on more than one hostname
+// connect from (several machines behind one NAT/egress point), with the
+// number of distinct hostnames behind each
+func findSharedEgress(agents []Agent) map[string]int {
+	// Single pass: group hostnames by IP
+	hostsByIP := make(map[string]map[string]bool)
+	for _, agent := range agents {
+		ip := agentIP(agent.RemoteAddress)
+		if ip == "" {
+			continue
+		}
+		if hostsByIP[ip] == nil {
+			hostsByIP[ip] = make(map[string]bool)
+		}
+		hostsByIP[ip][strings.ToLower(agent.Hostname)] = true
+	}
+	
+	shared := make(map[string]int)
+	for ip, hosts := range hostsByIP {
+		if len(hosts) > 1 {
+			shared[ip] = len(hosts)
+		}
+	}
+	return shared
+}
+
+// findDuplicatePIDs returns the IDs of agents that share a PID with another
+// agent on the same hostname (likely a duplicate/re-registered implant)
+func findDuplicatePIDs(agents []Agent) map[string]bool {
+	// Single pass: group agent IDs by hostname+PID
+	byHostPID := make(map[string][]string)
+	for _, agent := range agents {
+		if agent.PID == 0 {
+			continue // PID not reported
+		}
+		key := fmt.Sprintf("%s|%d", strings.ToLower(agent.Hostname), agent.PID)
+		byHostPID[key] = append(byHostPID[key], agent.ID)
+	}
+	
+	duplicates := make(map[string]bool)
+	for _, ids := range byHostPID {
+		if len(ids) < 2 {
+			continue
+		}
+		for _, id := range ids {
+			duplicates[id] = true
+		}
+	}
+	return duplicates
+}
+
+// unknownVersion is the bucket for agents that don't report an implant version
+const unknownVersion = "unknown"
+
+// findVersionSkew returns the most common implant version and the IDs of
+// agents running a different one. Agents without a version are their own
+// "unknown" bucket and are never counted as skewed. Ties go to the
+// lexically greatest version (usually the newest build).
+func findVersionSkew(agents []Agent) (string, map[string]bool) {
+	counts := make(map[string]int)
+	for _, agent := range agents {
+		if agent.Version != "" {
+			counts[agent.Version]++
+		}
+	}
+	
+	modal := ""
+	for version, count := range counts {
+		if count > counts[modal] || (count == counts[modal] && version > modal) {
+			modal = version
+		}
+	}
+	
+	skewed := make(map[string]bool)
+	for _, agent := range agents {
+		if agent.Version != "" && agent.Version != modal {
+			skewed[agent.ID] = true
+		}
+	}
+	return modal, skewed
+}
+
+// countIncomplete returns how many agents are still missing OS, transport
+// or address
+func countIncomplete(agents []Agent) int {
+	count := 0
+	for _, agent := range agents {
+		if agent.Incomplete {
+			count++
+		}
+	}
+	return count
+}
+
+// incompleteBadge marks agents the server sent without OS, transport or
+// address (usually mid-handshake; empty otherwise)
+func (m model) incompleteBadge(agent Agent) string {
+	if !agent.Incomplete {
+		return ""
+	}
+	return " " + lipgloss.NewStyle().
+		Foreground(m.theme.TacticalMuted).
+		Render("◌")
+}
+
+// skewBadge returns the old-build badge for an agent whose version differs
+// from the fleet's most common one (empty otherwise)
+func (m model) skewBadge(agent Agent) string {
+	if !m.skewedVersions[agent.ID] {
+		return ""
+	}
+	return " " + lipgloss.NewStyle().
+		Foreground(m.theme.WarningColor).
+		Bold(true).
+		Render("⇩")
+}
+
+// pivotCycleBadge flags an agent whose pivot parents loop back to it (drawn
+// at root level since the chain never reaches the C2)
+func (m model) pivotCycleBadge(agent Agent) string {
+	if !agent.PivotCycle {
+		return ""
+	}
+	return " " + lipgloss.NewStyle().
+		Foreground(m.theme.WarningColor).
+		Bold(true).
+		Render("⚠ pivot cycle")
+}
+
+// countUniqueHosts returns the number of distinct hostnames with a live agent
+func countUniqueHosts(agents []Agent) int {
+	hosts := make(map[string]bool)
+	for _, agent := range agents {
+		if agent.IsDead || agent.Hostname == "" {
+			continue
+		}
+		hosts[strings.ToLower(agent.Hostname)] = true
+	}
+	return len(hosts)
+}
+
+// baselineProgress summarizes owned hosts against the prefs baseline,
+// e.g. "42/50 hosts 84%" or "53/50 hosts +3". Returns "" when no baseline is set.
+func (m model) baselineProgress() (summary string, barLength int) {
+	if m.prefs == nil || m.prefs.Baseline <= 0 {
+		return "", 0
+	}
+	baseline := m.prefs.Baseline
+	hosts := countUniqueHosts(m.agents)
+	
+	if hosts > baseline {
+		// Past the expected scope - full bar plus the overflow
+		return fmt.Sprintf("%d/%d hosts +%d", hosts, baseline, hosts-baseline), 10
+	}
+	percentage := float64(hosts) / float64(baseline) * 100
+	return fmt.Sprintf("%d/%d hosts %.0f%%", hosts, baseline, percentage), int(percentage / 10) // 10% per block
 }
 
 // Agent is an alias to models.Agent
@@ -113,12 +725,10 @@ type Stats = models.Stats
 
 // SparklineCache stores pre-rendered sparklines
 type SparklineCache struct {
-	sessionSparkline    string
-	beaconSparkline     string
-	newAgentsSparkline  string
-	privilegedSparkline string
+	sparklines          map[string]string // Sparkline per metric name
 	timeAxis            string
 	lastSampleCount     int
+	ramp                string // Top ramp character the cache was drawn with (style changes redraw)
 	lastUpdate          time.Time
 }
 
@@ -134,11 +744,15 @@ const (
 type model struct {
 	agents          []Agent
 	stats           Stats
+	prevStats       Stats // Stats before the last change (footer flash baseline)
+	statsHistory    []Stats // Stats of the last few fetches, oldest first (footer trend arrows)
+	statFlashTicks  int   // Animation ticks left on the footer count flash
 	spinner         spinner.Model
 	viewport        viewport.Model // Scrollable viewport for agent list
 	loading         bool
 	err             error
 	lastUpdate      time.Time
+	connectedAt     time.Time // First successful fetch from the current server (engagement clock)
 	termWidth       int  // Terminal width for responsive layout
 	termHeight      int  // Terminal height
 	ready           bool // Viewport initialized
@@ -146,20 +760,24 @@ type model struct {
 	theme           config.Theme // Current theme
 	viewIndex       int  // Current view index
 	view            config.View // Current view
-	dashboardPage   int  // Current dashboard page (0-4)
+	dashboardPage   int  // Current dashboard page (index into dashboardPages)
 	activityTracker *ActivityTracker // Activity tracking over time
 	expandedSubnets map[string]bool  // Track which subnets are expanded
 	subnetOrder     []string         // Track subnet display order for numbered shortcuts
 	numberBuffer    string           // Buffer for multi-digit subnet number input
+	drillSubnet     string           // Subnet shown on the SUBNET dashboard page
 	alertManager    *alerts.AlertManager // Alert/notification system
 	previousAgents  map[string]Agent // Track previous agent state for change detection
 	animationFrame  int              // Frame counter for animations (arrows, etc.)
-	dnsCache        map[string]string // Cache for DNS lookups (IP -> domain)
-	domainCache     map[string]string // Cache for agent domains (sessionID -> domain)
+	dnsCache        *config.TTLCache // Cache for DNS lookups (IP -> domain)
+	dnsLookups      map[string]bool  // Remote addresses with a reverse DNS lookup in flight
+	domainCache     *config.TTLCache // Cache for agent domains (sessionID -> domain)
+	domainQueries   map[string]bool  // Session IDs with a domain query in flight
 	iconStyle       IconStyle        // Current icon style (Nerd Font or Emoji)
 	
 	// Performance optimization: content caching
 	cachedContent   string // Last rendered content
+	contentHash     uint64 // Hash of the content last passed to viewport.SetContent (0 = none)
 	contentDirty    bool   // Flag to force re-render
 	sparklineCache  SparklineCache // Cache for sparkline rendering
 	
@@ -173,14 +791,106 @@ type model struct {
 	showHelp        bool              // Flag to show/hide help menu
 	helpViewport    viewport.Model    // Viewport for scrolling help content
 	
+	// First-seen/lost agent tracking for the current server
+	tracker *tracking.Tracker
+	
+	// Operations log (task lifecycle timeline, 'L' to view; shares helpViewport)
+	opsLog     *tracking.OpsLog
+	tempo      *tracking.TempoTracker // Per-refresh activity for the tempo gauge
+	showOpsLog bool
+	
 	// Process path expansion
 	expandedProcessPaths map[string]bool // Track which agents have expanded process path (agentID -> expanded)
+	
+	// Duplicate implant detection
+	duplicatePIDs map[string]bool // Agent IDs sharing hostname+PID with another agent
+	sharedEgress  map[string]int  // IP -> hostnames connecting from it, for IPs shared by 2+ hosts
+	
+	// Implant version skew
+	modalVersion   string          // Most common implant version in the fleet
+	skewedVersions map[string]bool // Agent IDs running a different version
+	
+	// Acknowledged agents ('a' to toggle): alerts suppressed until the time passes
+	ackedAgents map[string]time.Time
+	
+	// Beacon ID -> current run of check-ins with tasks pending and none completed
+	taskStalls map[string]*taskStall
+	
+	// Agents that vanished within lostGracePeriod; their "lost" alert waits in
+	// case they come back under the same ID (then it's one "reconnected" alert)
+	pendingLost map[string]lostAgent
+	
+	// When the last activity sample was taken (timer or change-driven)
+	lastSampleAt time.Time
+	
+	// Agents hidden locally with 'x' (view filter only; 'X' unhides all).
+	// Kept for the session; IDs drop out once the server stops reporting them.
+	hiddenAgents map[string]bool
+	
+	// Persisted operator preferences
+	prefs *config.Prefs
+	
+	// Client options (per-transport dead tolerances, etc.)
+	clientOpts client.Options
+	
+	// Table view columns, in display order (validated keys of tableColumns)
+	tableColumns []string
+	
+	// Activity Metrics sparkline rows, in display order (validated names)
+	sparklineMetrics []string
+	
+	// Terminal below prefs min size (layout replaced by a message)
+	tooSmall bool
+	
+	// First blood banner (first agent seen this run)
+	firstAgentSeen bool   // Set once, never reset within a run
+	firstBloodHost string // Hostname shown in the banner ("" once dismissed)
+	
+	// Live privileged agents at the last refresh, for the fleet-wide
+	// "no privileged access" / "regained" alerts (-1 until the first fetch)
+	prevPrivileged int
+	
+	// Agent search ('/' to type, n/N to cycle matches)
+	searchMode    bool     // Typing a query
+	searchQuery   string   // Active query ("" = no search)
+	searchMatches []string // Matching agent IDs in display order
+	searchIndex   int      // Current match in searchMatches
+	
+	// Server switch ('S'): config awaiting y/n confirmation ("" = none)
+	pendingServer string
+	
+	// Flat agent list ('l'): Tree/Box views ignore pivot hierarchy
+	flatList bool
+	
+	// Terminal title last sent (prefs.WindowTitle; "" = not set)
+	windowTitle string
+	
+	// Latest resize; a resizeSettledMsg with an older seq is stale
+	resizeSeq int
+	
+	// Transport color legend under the footer ('K', hidden by default)
+	showLegend bool
+	
+	// Raw field inspector ('I' with DEBUG_PANEL=1; shares helpViewport)
+	inspectAgentID string // Agent being inspected ("" = closed)
+	
+	// Compound agent filter ('F' picker). agents/stats hold the filtered
+	// view; allAgents/allStats the unfiltered last fetch.
+	allAgents        []Agent
+	allStats         Stats
+	showFilterPicker bool
+	filterCursor     int // Highlighted row in config.AgentFilterPresets()
+	showThemePicker  bool
+	themeCursor      int // Highlighted row in m.themePickerOrder()
+	expandedAlertID  string // Summary alert showing its member list ("" = none)
+	demoFleet        *demo.Fleet // Synthetic data source in demo mode (nil = live server)
+	operators        []string    // Operators online on the server (nil = not reported)
 }
 
 func (m model) Init() tea.Cmd {
 	return tea.Batch(
 		m.spinner.Tick,
-		fetchAgentsCmd,
+		m.fetchCmd(),
 		sampleActivityCmd, // Start activity sampling timer
 		pulseTimerCmd,     // Start pulse animation timer for alerts
 		animationTickCmd,  // Start animation frame timer for flowing arrows
@@ -193,10 +903,35 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "q", "ctrl+c":
-			return m, tea.Quit
-		case "?":
+		// Search prompt captures all keys while typing
+		if m.searchMode {
+			return m.handleSearchKey(msg)
+		}
+		
+		// Server switch confirmation captures all keys until answered
+		if m.pendingServer != "" {
+			switch msg.String() {
+			case "ctrl+c":
+				return m, tea.Quit
+			case "y", "Y":
+				m.clientOpts.ConfigPath = m.pendingServer
+				m.pendingServer = ""
+				m.resetServerState()
+				m.alertManager.AddAlert(alerts.AlertNotice, alerts.CategoryC2Connected,
+					"Switching server", filepath.Base(m.clientOpts.ConfigPath), "")
+				m.loading = true
+				return m, m.fetchCmd()
+			default:
+				m.pendingServer = ""
+				m.fitViewport()
+			}
+			return m, nil
+		}
+		
+		switch msg.String() {
+		case "q", "ctrl+c":
+			return m, tea.Quit
+		case "?":
 			// Toggle help menu
 			m.showHelp = !m.showHelp
 			// When opening help, initialize viewport content
@@ -209,6 +944,94 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		
+		// Filter picker: move, pick (Enter or 1-9) and close
+		if m.showFilterPicker {
+			presets := config.AgentFilterPresets()
+			switch key := msg.String(); key {
+			case "up", "k":
+				m.filterCursor = (m.filterCursor - 1 + len(presets)) % len(presets)
+			case "down", "j":
+				m.filterCursor = (m.filterCursor + 1) % len(presets)
+			case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+				if index := int(key[0] - '1'); index < len(presets) {
+					m.filterCursor = index
+					m.setAgentFilter(presets[index])
+				}
+			case "enter":
+				m.setAgentFilter(presets[m.filterCursor])
+			case "F", "esc":
+				m.showFilterPicker = false
+			}
+			return m, nil
+		}
+		
+		// Theme picker: move, pick and close
+		if m.showThemePicker {
+			order := m.themePickerOrder()
+			switch msg.String() {
+			case "up", "k":
+				m.themeCursor = (m.themeCursor - 1 + len(order)) % len(order)
+			case "down", "j":
+				m.themeCursor = (m.themeCursor + 1) % len(order)
+			case "enter":
+				m.setTheme(order[m.themeCursor])
+				m.showThemePicker = false
+			case "alt+t", "esc":
+				m.showThemePicker = false
+			}
+			return m, nil
+		}
+		
+		// Raw field inspector overlay: scrolling and close
+		if m.inspectAgentID != "" {
+			switch msg.String() {
+			case "up", "k":
+				m.helpViewport.LineUp(1)
+			case "down", "j":
+				m.helpViewport.LineDown(1)
+			case "pgup":
+				m.helpViewport.ViewUp()
+			case "pgdown":
+				m.helpViewport.ViewDown()
+			case "home", "g":
+				m.helpViewport.GotoTop()
+			case "end", "G":
+				m.helpViewport.GotoBottom()
+			case "I", "esc":
+				m.inspectAgentID = ""
+			}
+			return m, nil
+		}
+		
+		// Operations log overlay: scrolling, export and close
+		if m.showOpsLog {
+			switch msg.String() {
+			case "up", "k":
+				m.helpViewport.LineUp(1)
+			case "down", "j":
+				m.helpViewport.LineDown(1)
+			case "pgup":
+				m.helpViewport.ViewUp()
+			case "pgdown":
+				m.helpViewport.ViewDown()
+			case "home", "g":
+				m.helpViewport.GotoTop()
+			case "end", "G":
+				m.helpViewport.GotoBottom()
+			case "e":
+				path := fmt.Sprintf("sliver-tui-opslog-%s.tsv", time.Now().Format("20060102-150405"))
+				if err := m.opsLog.Export(path); err != nil {
+					m.alertManager.AddAlert(alerts.AlertWarning, alerts.CategorySystemNotice, err.Error(), "ops log", "")
+				} else {
+					m.alertManager.AddAlertWithDetails(alerts.AlertNotice, alerts.CategorySystemNotice,
+						"Ops log exported", "ops log", "", path)
+				}
+			case "L", "esc":
+				m.showOpsLog = false
+			}
+			return m, nil
+		}
+		
 		// If help menu is open, handle scrolling keys
 		if m.showHelp {
 			switch msg.String() {
@@ -239,9 +1062,133 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		
 		// Normal view key handling
 		switch msg.String() {
+		case "L":
+			// Open operations log (newest entries at the bottom)
+			m.showOpsLog = true
+			m.helpViewport.SetContent(m.buildOpsLogContent())
+			m.helpViewport.GotoBottom()
+			return m, nil
+		
 		case "r":
 			m.loading = true
-			return m, fetchAgentsCmd
+			return m, m.fetchCmd()
+		
+		// Retry failed domain queries (resolved domains stay cached)
+		case "R":
+			live := make(map[string]bool)
+			for _, agent := range m.allAgents {
+				if agent.IsSession && !agent.IsDead {
+					live[agent.ID] = true
+				}
+			}
+			var retries []tea.Cmd
+			for _, sessionID := range m.domainCache.PurgeMisses() {
+				if live[sessionID] && !m.domainQueries[sessionID] && m.demoFleet == nil {
+					m.domainQueries[sessionID] = true
+					retries = append(retries, queryDomainCmd(sessionID, m.clientOpts))
+				}
+			}
+			message := "No failed domain queries to retry"
+			if len(retries) > 0 {
+				message = fmt.Sprintf("Retrying %d failed domain lookup(s)", len(retries))
+			}
+			m.alertManager.AddAlert(alerts.AlertNotice, alerts.CategorySystemNotice, message, "domains", "")
+			return m, tea.Batch(retries...)
+		
+		// Raw field inspector for the selected agent (troubleshooting only)
+		case "I":
+			if !inspectorEnabled() {
+				return m, nil
+			}
+			if m.selectedAgentID == "" {
+				m.alertManager.AddAlert(alerts.AlertNotice, alerts.CategorySystemNotice, "Select an agent to inspect", "inspector", "")
+				return m, nil
+			}
+			m.inspectAgentID = m.selectedAgentID
+			m.helpViewport.SetContent(m.buildInspectorContent())
+			m.helpViewport.GotoTop()
+			return m, nil
+		
+		// Export agent IPs (respecting the filter) to a file and the clipboard:
+		// y = all, or subnet #N if one is typed in the number buffer; Y = privileged only
+		case "y", "Y":
+			scope, keep := "all", func(Agent) bool { return true }
+			if msg.String() == "Y" {
+				scope, keep = "privileged", func(agent Agent) bool { return agent.IsPrivileged }
+			} else if m.numberBuffer != "" {
+				subnetNum, err := strconv.Atoi(m.numberBuffer)
+				m.numberBuffer = ""
+				if err != nil || subnetNum < 1 || subnetNum > len(m.subnetOrder) {
+					m.alertManager.AddAlert(alerts.AlertNotice, alerts.CategorySystemNotice,
+						fmt.Sprintf("No subnet #%d (1-%d)", subnetNum, len(m.subnetOrder)), "export", "")
+					return m, nil
+				}
+				subnet := m.subnetOrder[subnetNum-1]
+				scope = strings.NewReplacer(".", "_", "/", "_").Replace(subnet)
+				keep = func(agent Agent) bool { return extractSubnet(agent.RemoteAddress) == subnet }
+			}
+			
+			ips := collectIPs(m.agents, keep)
+			if len(ips) == 0 {
+				m.alertManager.AddAlert(alerts.AlertNotice, alerts.CategorySystemNotice, "No IPs to export", "export", "")
+				return m, nil
+			}
+			path, err := writeIPList(ips, scope)
+			if err != nil {
+				m.alertManager.AddAlert(alerts.AlertWarning, alerts.CategorySystemNotice, err.Error(), "export", "")
+				return m, nil
+			}
+			m.alertManager.AddAlertWithDetails(alerts.AlertNotice, alerts.CategorySystemNotice,
+				fmt.Sprintf("Exported %d IPs (%s)", len(ips), scope), "export", "", path+" + clipboard")
+			if m.ready {
+				m.updateViewportContent()
+			}
+			return m, clipboardCmd(strings.Join(ips, "\n"))
+		
+		// Snapshot the current screen to ANSI + plain text files
+		case "s":
+			ansiPath, plainPath, err := writeSnapshot(m.View())
+			if err != nil {
+				m.alertManager.AddAlert(alerts.AlertWarning, alerts.CategorySystemNotice, err.Error(), "snapshot", "")
+			} else {
+				m.alertManager.AddAlertWithDetails(alerts.AlertNotice, alerts.CategorySystemNotice,
+					"Screen snapshot saved", "snapshot", "", ansiPath+" + "+plainPath)
+			}
+			return m, nil
+		
+		// Switch to the next operator config (asks for confirmation)
+		case "S":
+			configs, err := client.ListConfigFiles()
+			if err != nil {
+				m.alertManager.AddAlert(alerts.AlertWarning, alerts.CategorySystemNotice, err.Error(), "server", "")
+				return m, nil
+			}
+			current, _ := client.ResolveConfigPath(m.clientOpts.ConfigPath)
+			next := configs[0]
+			for i, path := range configs {
+				if path == current {
+					next = configs[(i+1)%len(configs)]
+					break
+				}
+			}
+			if next == current {
+				m.alertManager.AddAlert(alerts.AlertNotice, alerts.CategorySystemNotice, "No other server configs", "server", "")
+				return m, nil
+			}
+			m.pendingServer = next
+			m.fitViewport()
+			return m, nil
+		
+		// Compound privilege/type filter picker
+		case "F":
+			m.showFilterPicker = true
+			m.filterCursor = 0
+			for i, preset := range config.AgentFilterPresets() {
+				if m.prefs != nil && preset == m.prefs.AgentFilter {
+					m.filterCursor = i
+				}
+			}
+			return m, nil
 		
 		// Dashboard keybind
 		case "d":
@@ -254,8 +1201,19 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 		
-		// Hidden Tree view (undocumented easter egg) - Ctrl+T
-		case "ctrl+t":
+		// Direct view selection: alt+1..4 follow the 'v' cycle order
+		// (a modifier keeps plain digits free for the subnet number buffer)
+		case "alt+1", "alt+2", "alt+3", "alt+4":
+			m.viewIndex = int(msg.String()[len("alt+")] - '1')
+			m.view = config.GetView(m.viewIndex)
+			m.contentDirty = true
+			if m.ready {
+				m.updateViewportContent()
+			}
+			return m, nil
+		
+		// Hidden Tree view (undocumented easter egg) - Ctrl+T / alt+5
+		case "ctrl+t", "alt+5":
 			// Switch to hidden Tree view
 			m.view = config.View{Name: "Tree", Type: config.ViewTypeTree}
 			m.viewIndex = -1 // Special index for hidden view
@@ -267,15 +1225,22 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		
 		// config.Theme switching
 		case "t":
-			m.themeIndex = (m.themeIndex + 1) % config.GetThemeCount()
-			m.theme = config.GetTheme(m.themeIndex)
-			m.contentDirty = true
-			// Update viewport content with new theme
-			if m.ready {
-				m.updateViewportContent()
+			m.setTheme((m.themeIndex + 1) % config.GetThemeCount())
+			return m, nil
+		
+		// Quick swap back to the previously used theme
+		case "T":
+			if m.prefs != nil && len(m.prefs.RecentThemes) > 1 {
+				m.setTheme(m.prefs.RecentThemes[1])
 			}
 			return m, nil
 		
+		// Theme picker (recently used themes listed first)
+		case "alt+t":
+			m.showThemePicker = true
+			m.themeCursor = 0
+			return m, nil
+		
 		// Icon style toggle
 		case "i":
 			// Toggle between Nerd Font and Emoji icons
@@ -293,7 +1258,11 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		
 		// config.View switching
 		case "v":
-			m.viewIndex = (m.viewIndex + 1) % config.GetViewCount()
+			var skip []string
+			if m.prefs != nil {
+				skip = m.prefs.CycleSkipViews
+			}
+			m.viewIndex = config.NextViewIndex(m.viewIndex, skip)
 			m.view = config.GetView(m.viewIndex)
 			m.contentDirty = true
 			// Update viewport content with new view
@@ -305,7 +1274,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Dashboard page navigation (when in dashboard view)
 		case "tab":
 			if m.viewIndex == 2 { // Dashboard view only
-				m.dashboardPage = (m.dashboardPage + 1) % 5 // 5 pages: 0-4
+				m.dashboardPage = (m.dashboardPage + 1) % len(dashboardPages)
 				m.contentDirty = true
 				if m.ready {
 					m.updateViewportContent()
@@ -315,7 +1284,36 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		
 		case "shift+tab":
 			if m.viewIndex == 2 { // Dashboard view only
-				m.dashboardPage = (m.dashboardPage - 1 + 5) % 5 // 5 pages: 0-4
+				m.dashboardPage = (m.dashboardPage - 1 + len(dashboardPages)) % len(dashboardPages)
+				m.contentDirty = true
+				if m.ready {
+					m.updateViewportContent()
+				}
+			}
+			return m, nil
+		
+		case "f1", "f2", "f3", "f4", "f5":
+			page := int(msg.String()[1] - '1')
+			if m.viewIndex == 2 && page < len(dashboardPages) {
+				m.dashboardPage = page
+				m.contentDirty = true
+				if m.ready {
+					m.updateViewportContent()
+				}
+			}
+			return m, nil
+		
+		// Expand/collapse subnets in network topology (dashboard and network map views)
+		case "e":
+			if m.view.Type == config.ViewTypeDashboard || m.view.Type == config.ViewTypeNetworkMap {
+				// Rebuild the subnet set from live agents so subnets discovered
+				// since the last render are included in the toggle
+				m.updateSubnetOrder()
+				toggleAllSubnets(m.expandedSubnets, m.subnetOrder)
+				
+				m.saveSubnetPrefs()
+				
+				// Mark content as dirty and update viewport
 				m.contentDirty = true
 				if m.ready {
 					m.updateViewportContent()
@@ -323,9 +1321,28 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 		
-		case "f1":
-			if m.viewIndex == 2 {
-				m.dashboardPage = 0
+		// Toggle flat list (ignore pivot hierarchy in Tree/Box views)
+		case "l":
+			m.flatList = !m.flatList
+			layout := "pivot tree"
+			if m.flatList {
+				layout = "flat"
+			}
+			m.alertManager.AddAlert(alerts.AlertNotice, alerts.CategorySystemNotice,
+				"Agent list: "+layout, "view", "")
+			m.contentDirty = true
+			if m.ready {
+				m.updateViewportContent()
+			}
+			return m, nil
+		
+		// Cycle dead agent placement (mixed → bottom → top)
+		case "D":
+			if m.prefs != nil {
+				m.prefs.DeadPlacement = config.NextDeadPlacement(m.prefs.DeadPlacement)
+				m.savePrefs()
+				m.alertManager.AddAlert(alerts.AlertNotice, alerts.CategorySystemNotice,
+					"Dead agents: "+m.prefs.DeadPlacement, "view", "")
 				m.contentDirty = true
 				if m.ready {
 					m.updateViewportContent()
@@ -333,9 +1350,23 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 		
-		case "f2":
-			if m.viewIndex == 2 {
-				m.dashboardPage = 1
+		// Cycle the header debug fields (scroll → scroll+term → none)
+		case "V":
+			if m.prefs != nil {
+				m.prefs.HeaderDebug = config.NextHeaderDebug(m.prefs.HeaderDebug)
+				m.savePrefs()
+				m.alertManager.AddAlert(alerts.AlertNotice, alerts.CategorySystemNotice,
+					"Header debug text: "+m.prefs.HeaderDebug, "view", "")
+			}
+			return m, nil
+		
+		// Cycle the Task Queue Monitor order (pending → completion → check-in)
+		case "O":
+			if m.prefs != nil {
+				m.prefs.TaskSort = config.NextTaskSort(m.prefs.TaskSort)
+				m.savePrefs()
+				m.alertManager.AddAlert(alerts.AlertNotice, alerts.CategorySystemNotice,
+					"Task queue order: "+m.prefs.TaskSort, "view", "")
 				m.contentDirty = true
 				if m.ready {
 					m.updateViewportContent()
@@ -343,9 +1374,11 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 		
-		case "f3":
-			if m.viewIndex == 2 {
-				m.dashboardPage = 2
+		// Toggle theme state backgrounds on agent rows/boxes
+		case "B":
+			if m.prefs != nil {
+				m.prefs.AgentBackgrounds = !m.prefs.AgentBackgrounds
+				m.savePrefs()
 				m.contentDirty = true
 				if m.ready {
 					m.updateViewportContent()
@@ -353,9 +1386,11 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 		
-		case "f4":
-			if m.viewIndex == 2 {
-				m.dashboardPage = 3
+		// Toggle privileged agent background emphasis
+		case "P":
+			if m.prefs != nil {
+				m.prefs.PrivilegedEmphasis = !m.prefs.PrivilegedEmphasis
+				m.savePrefs()
 				m.contentDirty = true
 				if m.ready {
 					m.updateViewportContent()
@@ -363,9 +1398,30 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 		
-		case "f5":
-			if m.viewIndex == 2 {
-				m.dashboardPage = 4
+		// Toggle the transport color legend
+		case "K":
+			m.showLegend = !m.showLegend
+			m.fitViewport()
+			return m, nil
+		
+		// Toggle fleet status in the terminal window title
+		case "W":
+			if m.prefs != nil {
+				m.prefs.WindowTitle = !m.prefs.WindowTitle
+				m.savePrefs()
+				if !m.prefs.WindowTitle {
+					m.windowTitle = ""
+					return m, tea.SetWindowTitle("")
+				}
+				return m, m.updateWindowTitle()
+			}
+			return m, nil
+		
+		// Toggle short usernames (strip "DOMAIN\" in list/table views)
+		case "u":
+			if m.prefs != nil {
+				m.prefs.ShortUsernames = !m.prefs.ShortUsernames
+				m.savePrefs()
 				m.contentDirty = true
 				if m.ready {
 					m.updateViewportContent()
@@ -373,38 +1429,96 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 		
-		// Expand/collapse subnets in network topology (dashboard and network map views)
-		case "e":
-			if m.view.Type == config.ViewTypeDashboard || m.view.Type == config.ViewTypeNetworkMap {
-				// Toggle all subnets
-				allExpanded := true
-				for subnet := range m.expandedSubnets {
-					if !m.expandedSubnets[subnet] {
-						allExpanded = false
+		// Toggle counting unique hosts instead of agent connections
+		case "c":
+			if m.prefs != nil {
+				m.prefs.CountHosts = !m.prefs.CountHosts
+				m.savePrefs()
+				m.agents, m.stats = m.filterAgents()
+				m.prevStats = m.stats // A unit change, not a fleet change; don't flash
+				m.statsHistory = nil  // ...or a trend
+				m.recordStatsHistory()
+				m.contentDirty = true
+				if m.ready {
+					m.updateViewportContent()
+				}
+			}
+			return m, nil
+		
+		// Toggle quiet mode (hide help footer, header debug text, non-critical alerts)
+		case "z":
+			if m.prefs != nil {
+				m.prefs.QuietMode = !m.prefs.QuietMode
+				m.savePrefs()
+				m.fitViewport()
+			}
+			return m, nil
+		
+		// Toggle alert timestamps between absolute and relative
+		case "w":
+			if m.prefs != nil {
+				m.prefs.RelativeAlertTimes = !m.prefs.RelativeAlertTimes
+				m.savePrefs()
+			}
+			return m, nil
+		
+		// Toggle the Network Map symbol legend
+		case "m":
+			if m.prefs != nil {
+				m.prefs.MapLegend = !m.prefs.MapLegend
+				m.savePrefs()
+				m.contentDirty = true
+				if m.ready {
+					m.updateViewportContent()
+				}
+			}
+			return m, nil
+		
+		// Hide the selected agent from the views and counts (local only)
+		case "x":
+			if m.selectedAgentID != "" {
+				for _, agent := range m.agents {
+					if agent.ID == m.selectedAgentID {
+						m.alertManager.AddAlertWithDetails(alerts.AlertNotice, alerts.CategorySystemNotice,
+							"Agent hidden", agent.Hostname, "", "(X to unhide all)")
 						break
 					}
 				}
-				
-				// If all expanded, collapse all; otherwise expand all
-				for subnet := range m.expandedSubnets {
-					m.expandedSubnets[subnet] = !allExpanded
-				}
-				
-				// For new subnets, expand them
-				if !allExpanded {
-					// Get all current subnets
-					for _, agent := range m.agents {
-						if agent.IsDead {
-							continue
-						}
-						subnet := extractSubnet(agent.RemoteAddress)
-						if subnet != "" {
-							m.expandedSubnets[subnet] = true
-						}
+				m.hiddenAgents[m.selectedAgentID] = true
+				m.selectedAgentID = ""
+				m.refilterAgents()
+			}
+			return m, nil
+		
+		// Unhide every locally hidden agent
+		case "X":
+			if len(m.hiddenAgents) > 0 {
+				m.alertManager.AddAlert(alerts.AlertNotice, alerts.CategorySystemNotice,
+					fmt.Sprintf("%d agent(s) unhidden", len(m.hiddenAgents)), "", "")
+				m.hiddenAgents = make(map[string]bool)
+				m.refilterAgents()
+			}
+			return m, nil
+		
+		// Acknowledge selected agent (silence its alerts for ackDuration)
+		case "a":
+			if m.selectedAgentID != "" {
+				hostname := m.selectedAgentID
+				for _, agent := range m.agents {
+					if agent.ID == m.selectedAgentID {
+						hostname = agent.Hostname
+						break
 					}
 				}
-				
-				// Mark content as dirty and update viewport
+				if m.isAcked(m.selectedAgentID) {
+					delete(m.ackedAgents, m.selectedAgentID)
+					m.alertManager.AddAlert(alerts.AlertNotice, alerts.CategorySystemNotice,
+						"Acknowledgement cleared", hostname, m.selectedAgentID)
+				} else {
+					m.ackedAgents[m.selectedAgentID] = time.Now().Add(ackDuration)
+					m.alertManager.AddAlertWithDetails(alerts.AlertNotice, alerts.CategorySystemNotice,
+						"Alerts silenced", hostname, m.selectedAgentID, fmt.Sprintf("(%s)", ackDuration))
+				}
 				m.contentDirty = true
 				if m.ready {
 					m.updateViewportContent()
@@ -428,6 +1542,14 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Multi-digit subnet number input (accumulate digits in buffer)
 		case "0", "1", "2", "3", "4", "5", "6", "7", "8", "9":
 			if m.viewIndex == 2 { // Dashboard view only
+				// Leading zeros mean nothing in a 1-based subnet number
+				if m.numberBuffer == "" && msg.String() == "0" {
+					return m, nil
+				}
+				// No subnet number has more digits than the subnet count
+				if len(m.numberBuffer) >= len(strconv.Itoa(max(len(m.subnetOrder), 1))) {
+					return m, nil
+				}
 				// Append digit to buffer
 				m.numberBuffer += msg.String()
 				// Update viewport to show the buffer indicator
@@ -437,20 +1559,33 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 		
-		// Enter key - activate subnet selection from buffer
-		case "enter":
+		// Enter toggles the buffered subnet's expansion, leaving the others
+		// as they are; 'o' focuses it (expand it, collapse the rest); '>' (or
+		// Enter on the SUBNET page) drills into it on the SUBNET dashboard page
+		case "enter", ">", "o":
 			if m.viewIndex == 2 && len(m.numberBuffer) > 0 {
-				// Convert buffer to integer
-				subnetNum := 0
-				for _, ch := range m.numberBuffer {
-					subnetNum = subnetNum*10 + int(ch-'0')
-				}
+				// Convert buffer to integer (the buffer is length-capped digits)
+				subnetNum, err := strconv.Atoi(m.numberBuffer)
 				subnetNum-- // Convert 1-based to 0-based index
 				
-				// Toggle subnet if valid
-				if subnetNum >= 0 && subnetNum < len(m.subnetOrder) {
+				drillDown := msg.String() == ">" || dashboardPages[m.dashboardPage].name == subnetPageName
+				if err == nil && subnetNum >= 0 && subnetNum < len(m.subnetOrder) {
 					subnet := m.subnetOrder[subnetNum]
-					m.expandedSubnets[subnet] = !m.expandedSubnets[subnet]
+					if drillDown {
+						m.drillSubnet = subnet
+						m.dashboardPage = dashboardPageIndex(subnetPageName)
+						m.contentDirty = true
+					} else if msg.String() == "o" {
+						focusSubnet(m.expandedSubnets, m.subnetOrder, subnet)
+						m.saveSubnetPrefs()
+						m.contentDirty = true
+					} else {
+						m.expandedSubnets[subnet] = !m.expandedSubnets[subnet]
+						m.saveSubnetPrefs()
+					}
+				} else {
+					m.alertManager.AddAlert(alerts.AlertNotice, alerts.CategorySystemNotice,
+						fmt.Sprintf("No subnet #%s (1-%d)", m.numberBuffer, len(m.subnetOrder)), "subnet", "")
 				}
 				
 				// Clear buffer
@@ -463,9 +1598,34 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 		
-		// Escape key - clear number buffer and deselect agent
+		// Search agents (hostname, user, ID, IP, OS, transport)
+		case "/":
+			m.searchMode = true
+			m.searchQuery = ""
+			m.searchMatches = nil
+			m.searchIndex = 0
+			m.fitViewport()
+			return m, nil
+		
+		// Next/previous search match
+		case "n", "N":
+			if m.searchQuery != "" && len(m.searchMatches) > 0 {
+				if msg.String() == "n" {
+					m.searchIndex = (m.searchIndex + 1) % len(m.searchMatches)
+				} else {
+					m.searchIndex = (m.searchIndex - 1 + len(m.searchMatches)) % len(m.searchMatches)
+				}
+				m.jumpToSearchMatch()
+			}
+			return m, nil
+		
+		// Escape key - clear number buffer, banners and search, deselect agent
 		case "esc":
 			m.numberBuffer = ""
+			m.firstBloodHost = "" // Dismiss first blood banner
+			m.searchQuery = ""    // Clear search
+			m.searchMatches = nil
+			m.fitViewport()
 			if m.selectedAgentID != "" {
 				m.selectedAgentID = ""
 				m.contentDirty = true
@@ -495,8 +1655,8 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case tea.MouseMsg:
-		// If help menu is open, handle mouse scrolling
-		if m.showHelp {
+		// If help menu, ops log or inspector is open, handle mouse scrolling
+		if m.showHelp || m.showOpsLog || m.inspectAgentID != "" {
 			switch msg.Type {
 			case tea.MouseWheelUp:
 				m.helpViewport.LineUp(3)
@@ -561,8 +1721,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.alertManager != nil {
 				activeAlerts := m.alertManager.GetAlerts()
 				if len(activeAlerts) > 0 {
-					// Alert panel height = 1 (top border) + num alerts + 1 (bottom border)
-					alertPanelHeight := len(activeAlerts) + 2
+					// Alert panel height = 1 (top border) + alert rows + 1 (bottom border)
+					alertRows := m.alertRows(activeAlerts)
+					alertPanelHeight := len(alertRows) + 2
 					
 					// Alerts are rendered near the bottom of the screen
 					// They appear after footer text (stats, help, etc)
@@ -580,8 +1741,21 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						// Adjust for the 2-line offset (seems to be off by 2)
 						alertIndex = alertIndex + 2
 						
-						if alertIndex >= 0 && alertIndex < len(activeAlerts) {
-							alert := activeAlerts[alertIndex]
+						if alertIndex >= 0 && alertIndex < len(alertRows) {
+							alert := activeAlerts[alertRows[alertIndex]]
+							if alert.IsSummary() {
+								// Expand (or collapse) the burst's host list
+								if m.expandedAlertID == alert.ID {
+									m.expandedAlertID = ""
+								} else {
+									m.expandedAlertID = alert.ID
+								}
+								m.contentDirty = true
+								if m.ready {
+									m.updateViewportContent()
+								}
+								return m, nil
+							}
 							if alert.AgentID != "" {
 								// Jump to and select the agent
 								m.selectedAgentID = alert.AgentID
@@ -613,13 +1787,18 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.termWidth = msg.Width
 		m.termHeight = msg.Height
 		
+		// Below the minimum size the layout is unreadable - View shows a notice
+		// instead, and the normal UI comes back once the terminal is enlarged
+		m.tooSmall = m.prefs != nil &&
+			(msg.Width < m.prefs.MinWidth || msg.Height < m.prefs.MinHeight)
+		
 		if !m.ready {
 			// Initialize viewport on first window size message
 			// Header: title(1 line, no border) + status(1) + empty(1) = 3 lines
 			// Footer: empty(1) + separator(1) + empty(1) + stats(1) + lost?(0-1) + empty(1) + help(1) + empty(1) = ~7 lines
-			headerFooterHeight := 10 // Reserve 3 for header + 7 for footer
-			m.viewport = viewport.New(msg.Width, msg.Height-headerFooterHeight)
+			m.viewport = viewport.New(msg.Width, max(msg.Height-m.chromeHeight(), 1))
 			m.viewport.YPosition = 3 // Start after header (3 lines)
+			m.contentHash = 0        // Fresh viewport has no content yet
 			
 			// Initialize help viewport
 			helpWidth := 90
@@ -635,9 +1814,8 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.ready = true
 		} else {
 			// Update viewport dimensions on resize
-			headerFooterHeight := 10
 			m.viewport.Width = msg.Width
-			m.viewport.Height = msg.Height - headerFooterHeight
+			m.viewport.Height = max(msg.Height-m.chromeHeight(), 1)
 			
 			// Update help viewport dimensions if help is open
 			if m.showHelp {
@@ -652,28 +1830,97 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.helpViewport.Width = helpWidth
 				m.helpViewport.Height = helpHeight
 			}
+			
+			// Dimensions apply immediately; the content reflow waits for
+			// the drag to settle
+			m.resizeSeq++
+			seq := m.resizeSeq
+			return m, tea.Tick(resizeDebounce, func(time.Time) tea.Msg {
+				return resizeSettledMsg{seq: seq}
+			})
 		}
 		
 		return m, nil
+	
+	case resizeSettledMsg:
+		// A newer resize is still pending - let that one reflow
+		if msg.seq != m.resizeSeq {
+			return m, nil
+		}
+		m.contentDirty = true
+		if m.ready {
+			m.updateViewportContent()
+		}
+		return m, nil
 
 	case spinner.TickMsg:
 		m.spinner, cmd = m.spinner.Update(msg)
 		cmds = append(cmds, cmd)
 
 	case agentsMsg:
+		// Drop results from a server we've since switched away from
+		if msg.configPath != m.clientOpts.ConfigPath {
+			return m, nil
+		}
+		
 		// Detect changes and generate alerts
-		m.detectAgentChanges(msg.agents)
+		m.pruneExpiredAcks()
+		m.detectAgentChanges(msg.agents, msg.stats)
+		m.trackTaskStalls(msg.agents)
+		
+		// Keep an open ops log current (stay pinned to the newest entries)
+		if m.showOpsLog {
+			atBottom := m.helpViewport.AtBottom()
+			m.helpViewport.SetContent(m.buildOpsLogContent())
+			if atBottom {
+				m.helpViewport.GotoBottom()
+			}
+		}
+		
+		m.allAgents = msg.agents
+		m.allStats = msg.stats
+		// Keep the last good list when the best-effort operators call failed
+		if msg.operators != nil {
+			m.operators = msg.operators
+		}
+		
+		// Forget hidden agents the server no longer reports
+		if len(m.hiddenAgents) > 0 {
+			reported := make(map[string]bool, len(msg.agents))
+			for _, agent := range msg.agents {
+				reported[agent.ID] = true
+			}
+			for id := range m.hiddenAgents {
+				if !reported[id] {
+					delete(m.hiddenAgents, id)
+				}
+			}
+		}
 		
-		m.agents = msg.agents
-		m.stats = msg.stats
+		// Keep an open inspector showing the latest raw values
+		if m.inspectAgentID != "" {
+			m.helpViewport.SetContent(m.buildInspectorContent())
+		}
+		agents, stats := m.filterAgents()
+		m.agents = agents
+		// Flash changed footer counts (not on the first fetch)
+		if stats != m.stats && m.stats != (Stats{}) {
+			m.prevStats = m.stats
+			m.statFlashTicks = statFlashDuration
+		}
+		m.stats = stats
+		m.recordStatsHistory()
+		m.duplicatePIDs = findDuplicatePIDs(msg.agents)
+		m.sharedEgress = findSharedEgress(msg.agents)
+		m.modalVersion, m.skewedVersions = findVersionSkew(msg.agents)
 		m.loading = false
 		m.lastUpdate = time.Now()
+		if m.connectedAt.IsZero() {
+			m.connectedAt = m.lastUpdate
+		}
 		m.err = nil
 		m.contentDirty = true // Mark content as needing re-render
 		
-		// Sample activity immediately when agents are fetched
-		m.sampleCurrentActivity()
-		
 		// Update subnet order for numbered shortcuts
 		m.updateSubnetOrder()
 		
@@ -682,26 +1929,44 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.updateViewportContent()
 		}
 		
+		// Refresh search matches against the new agents (needs the new line map)
+		m.updateSearchMatches()
+		
+		// Keep the terminal title in step with the fleet
+		if cmd := m.updateWindowTitle(); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+		
+		// Reverse DNS for agents with no other domain source (non-blocking);
+		// expired cache entries are looked up again here
+		cmds = append(cmds, m.queueDNSLookups(msg.agents)...)
+		
 		// Trigger background domain queries for all sessions (non-blocking)
 		for _, agent := range msg.agents {
-			if agent.IsSession && !agent.IsDead {
-				// Check if we already have this domain cached
-				if _, exists := m.domainCache[agent.ID]; !exists {
+			if agent.IsSession && !agent.IsDead && m.demoFleet == nil {
+				// Check if we already have this domain cached (or on its way)
+				if _, exists := m.domainCache.Get(agent.ID); !exists && !m.domainQueries[agent.ID] {
 					// Launch background query
-					cmds = append(cmds, queryDomainCmd(agent.ID))
+					m.domainQueries[agent.ID] = true
+					cmds = append(cmds, queryDomainCmd(agent.ID, m.clientOpts))
 				}
 			}
 		}
 		
-		cmds = append(cmds, tea.Tick(5*time.Second, func(t time.Time) tea.Msg {
+		cmds = append(cmds, tea.Tick(m.refreshDelay(), func(t time.Time) tea.Msg {
 			return refreshMsg{}
 		}))
 
 	case domainQueryMsg:
-		// Domain query completed in background
-		if msg.domain != "" {
-			// Cache the domain result
-			m.domainCache[msg.sessionID] = msg.domain
+		// Domain query completed in background. Cache the result, even a
+		// failure (retried once its shorter TTL runs out). Queries queued
+		// before a server switch are dropped: session IDs are per server.
+		if msg.configPath != m.clientOpts.ConfigPath {
+			return m, nil
+		}
+		m.domainCache.Set(msg.sessionID, msg.domain)
+		delete(m.domainQueries, msg.sessionID)
+		if msg.domain != "" || len(m.domainQueries) == 0 {
 			// Mark content dirty to trigger re-render with new domain info
 			m.contentDirty = true
 			if m.ready {
@@ -709,7 +1974,22 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-	case activitySampleMsg:
+	case dnsLookupMsg:
+		// Reverse DNS lookup completed in background; results for a server
+		// we have switched away from are dropped
+		if msg.configPath != m.clientOpts.ConfigPath {
+			return m, nil
+		}
+		m.dnsCache.Set(msg.address, msg.domain)
+		delete(m.dnsLookups, msg.address)
+		if msg.domain != "" {
+			m.contentDirty = true
+			if m.ready {
+				m.updateViewportContent()
+			}
+		}
+	
+	case activitySampleMsg:
 		// Sample activity when timer triggers
 		m.sampleCurrentActivity()
 		m.contentDirty = true // Mark for dashboard refresh
@@ -726,6 +2006,10 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.updateViewportContent()
 			}
 		}
+		// Critical alerts expiring changes the terminal title
+		if cmd := m.updateWindowTitle(); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
 		// Schedule next pulse update
 		cmds = append(cmds, pulseTimerCmd)
 
@@ -735,6 +2019,10 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.animationFrame > 3 {
 			m.animationFrame = 0
 		}
+		// Decay the footer count flash
+		if m.statFlashTicks > 0 {
+			m.statFlashTicks--
+		}
 		// Only mark dirty and update if we're on views with animations
 		if m.view.Type == config.ViewTypeNetworkMap || m.view.Type == config.ViewTypeBox || m.view.Type == config.ViewTypeTree {
 			m.contentDirty = true
@@ -747,19 +2035,32 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case refreshMsg:
 		m.loading = true
-		cmds = append(cmds, fetchAgentsCmd)
+		cmds = append(cmds, m.fetchCmd())
 
 	case errMsg:
+		// Drop failures from a server we've since switched away from
+		if msg.configPath != m.clientOpts.ConfigPath {
+			return m, nil
+		}
+		
+		// Only a successful fetch replaces agents, stats, the tracker and the
+		// caches; a failure keeps the last good board up under the stale
+		// data warning and keeps polling until the server is back
 		m.err = msg.err
 		m.loading = false
-		return m, nil
+		return m, tea.Tick(m.refreshDelay(), func(t time.Time) tea.Msg {
+			return refreshMsg{}
+		})
 	}
 
 	return m, tea.Batch(cmds...)
 }
 
-// detectAgentChanges compares current agents with previous state and generates alerts
-func (m *model) detectAgentChanges(newAgents []Agent) {
+// detectAgentChanges compares current agents with previous state and generates alerts.
+// Notable transitions (new/lost agent, privilege escalation) and the first
+// fetch also take an activity sample between timer ticks, so sparklines
+// catch the spike.
+func (m *model) detectAgentChanges(newAgents []Agent, newStats Stats) {
 	if m.alertManager == nil {
 		return
 	}
@@ -769,9 +2070,87 @@ func (m *model) detectAgentChanges(newAgents []Agent) {
 	for _, agent := range newAgents {
 		newAgentMap[agent.ID] = agent
 	}
+	
+	// First blood: the first agent seen this run gets a one-time banner.
+	// firstAgentSeen is never reset, so reconnects don't re-trigger it.
+	if !m.firstAgentSeen && len(newAgents) > 0 {
+		m.firstAgentSeen = true
+		first := newAgents[0]
+		for _, agent := range newAgents {
+			if !agent.IsDead {
+				first = agent
+				break
+			}
+		}
+		m.firstBloodHost = first.Hostname
+		m.fitViewport()
+		m.alertManager.AddAlertWithDetails(alerts.AlertSuccess, alerts.CategoryFirstBlood,
+			"First agent acquired", first.Hostname, first.ID, "(connection verified)")
+	}
+
+	// Beacon→session promotion: the session comes back under a new ID, so pair
+	// each vanished beacon with a new session from the same implant and raise a
+	// single "upgraded" alert instead of a lost+acquired pair
+	promoted := make(map[string]bool) // Old beacon IDs and new session IDs already handled
+	for id, agent := range newAgentMap {
+		if _, existed := m.previousAgents[id]; existed || !agent.IsSession {
+			continue
+		}
+		for oldID, oldAgent := range m.previousAgents {
+			if _, stillPresent := newAgentMap[oldID]; stillPresent || oldAgent.IsSession || promoted[oldID] {
+				continue
+			}
+			if !isSameImplant(oldAgent, agent) {
+				continue
+			}
+			promoted[oldID] = true
+			promoted[id] = true
+			if m.isAcked(oldID) {
+				// Carry the acknowledgement over to the new session
+				m.ackedAgents[id] = m.ackedAgents[oldID]
+			} else if agent.IsPrivileged {
+				m.alertManager.AddAlertWithDetails(alerts.AlertInfo, alerts.CategoryPrivilegedSessionOpened, 
+					"Beacon upgraded to privileged session", agent.Hostname, agent.ID, "(beacon→session)")
+			} else {
+				m.alertManager.AddAlertWithDetails(alerts.AlertInfo, alerts.CategorySessionOpened, 
+					"Beacon upgraded to session", agent.Hostname, agent.ID, "(beacon→session)")
+			}
+			break
+		}
+	}
 
+	raisedBefore := m.alertManager.Raised()
+	notable := false // New/lost agent or privilege escalation this refresh
+	tasksCompleted := 0
+	
+	// Same-ID reconnects: an agent lost within the grace period is back, so
+	// its held "lost" alert and the "acquired" alert become one "reconnected"
+	if m.pendingLost == nil {
+		m.pendingLost = make(map[string]lostAgent)
+	}
+	reconnected := make(map[string]bool)
+	for id, lost := range m.pendingLost {
+		agent, back := newAgentMap[id]
+		if !back {
+			continue
+		}
+		delete(m.pendingLost, id)
+		reconnected[id] = true
+		if !m.isAcked(id) {
+			m.alertManager.AddAlertWithDetails(alerts.AlertInfo, alerts.CategoryAgentReconnected,
+				"Agent reconnected", agent.Hostname, agent.ID,
+				fmt.Sprintf("(back after %s)", time.Since(lost.lostAt).Round(time.Second)))
+		}
+	}
+	
 	// Detect new agents (connected)
 	for _, agent := range newAgentMap {
+		if _, exists := m.previousAgents[agent.ID]; !exists && len(m.previousAgents) > 0 && !reconnected[agent.ID] {
+			notable = true
+		}
+		if m.isAcked(agent.ID) || promoted[agent.ID] || reconnected[agent.ID] {
+			continue // Operator acknowledged this agent, or it was a promotion/reconnect
+		}
 		if _, exists := m.previousAgents[agent.ID]; !exists {
 			// New agent connected
 			alertType := alerts.AlertSuccess
@@ -781,7 +2160,11 @@ func (m *model) detectAgentChanges(newAgents []Agent) {
 			details := fmt.Sprintf("(%d active)", activeCount)
 			
 			// Determine appropriate alert based on agent type and privilege
-			if agent.IsSession {
+			if m.isWatched(agent) {
+				// Watched hosts always raise a critical alert
+				m.alertManager.AddAlertWithDetails(alerts.AlertCritical, alerts.CategoryWatchedHostAcquired, 
+					"Watched host connected", agent.Hostname, agent.ID, details)
+			} else if agent.IsSession {
 				// Session-specific alerts
 				if agent.IsPrivileged {
 					m.alertManager.AddAlertWithDetails(alertType, alerts.CategoryPrivilegedSessionAcquired, 
@@ -806,18 +2189,33 @@ func (m *model) detectAgentChanges(newAgents []Agent) {
 	// Detect lost agents (disconnected)
 	for id, oldAgent := range m.previousAgents {
 		if _, exists := newAgentMap[id]; !exists {
-			// Agent disappeared - differentiate between session and beacon
-			if oldAgent.IsSession {
-				m.alertManager.AddAlert(alerts.AlertCritical, alerts.CategorySessionDisconnected, "Session lost", oldAgent.Hostname, oldAgent.ID)
-			} else {
-				m.alertManager.AddAlert(alerts.AlertCritical, alerts.CategoryBeaconDisconnected, "Beacon lost", oldAgent.Hostname, oldAgent.ID)
-			}
+			notable = true
+		}
+		if m.isAcked(id) || promoted[id] {
+			continue
+		}
+		if _, exists := newAgentMap[id]; !exists {
+			// Agent disappeared: hold the alert for the grace period
+			m.pendingLost[id] = lostAgent{agent: oldAgent, lostAt: time.Now()}
+		}
+	}
+	
+	// Agents still gone after the grace period are reported lost
+	var expiredLost []Agent
+	for id, lost := range m.pendingLost {
+		if time.Since(lost.lostAt) < lostGracePeriod {
+			continue
+		}
+		delete(m.pendingLost, id)
+		expiredLost = append(expiredLost, lost.agent)
+		if !m.isAcked(id) {
+			m.alertLost(lost.agent)
 		}
 	}
 
 	// Detect beacon late/missed check-ins
 	for _, agent := range newAgentMap {
-		if !agent.IsSession { // Only check beacons
+		if !agent.IsSession && !m.isAcked(agent.ID) { // Only check beacons
 			// Check if beacon is late (this logic should be in your Agent struct or tracking)
 			if agent.IsDead {
 				m.alertManager.AddAlert(alerts.AlertWarning, alerts.CategoryBeaconMissed, "Beacon missed check-in", agent.Hostname, agent.ID)
@@ -827,20 +2225,40 @@ func (m *model) detectAgentChanges(newAgents []Agent) {
 
 	// Detect session events and privilege changes
 	for id, newAgent := range newAgentMap {
-		if oldAgent, exists := m.previousAgents[id]; exists {
+		oldAgent, exists := m.previousAgents[id]
+		if exists && tracking.CompareState(oldAgent, newAgent).Privilege && newAgent.IsPrivileged {
+			notable = true
+		}
+		if m.isAcked(id) {
+			continue
+		}
+		if exists {
+			change := tracking.CompareState(oldAgent, newAgent)
+			
+			// Dead beacon checked in again (fires once, on the dead→alive transition)
+			if change.Status && !newAgent.IsDead {
+				m.alertManager.AddAlertWithDetails(alerts.AlertSuccess, alerts.CategoryBeaconResurrected, 
+					"Beacon resurrected", newAgent.Hostname, newAgent.ID, "(was dead)")
+			}
+			
 			// Check if privilege escalated (wasn't privileged before, is now)
-			if newAgent.IsPrivileged && !oldAgent.IsPrivileged {
+			if change.Privilege && newAgent.IsPrivileged {
 				agentType := "beacon"
 				if newAgent.IsSession {
 					agentType = "session"
 				}
 				details := fmt.Sprintf("(%s)", agentType)
-				m.alertManager.AddAlertWithDetails(alerts.AlertSuccess, alerts.CategoryPrivilegedAccess, 
-					"Privilege escalated", newAgent.Hostname, newAgent.ID, details)
+				if m.isWatched(newAgent) {
+					m.alertManager.AddAlertWithDetails(alerts.AlertCritical, alerts.CategoryWatchedHostEscalated, 
+						"Watched host privilege escalated", newAgent.Hostname, newAgent.ID, details)
+				} else {
+					m.alertManager.AddAlertWithDetails(alerts.AlertSuccess, alerts.CategoryPrivilegedAccess, 
+						"Privilege escalated", newAgent.Hostname, newAgent.ID, details)
+				}
 			}
 			
 			// Check if session state changed (beacon converted to session)
-			if newAgent.IsSession && !oldAgent.IsSession {
+			if change.Type && newAgent.IsSession {
 				details := "(beacon→session)"
 				if newAgent.IsPrivileged {
 					m.alertManager.AddAlertWithDetails(alerts.AlertInfo, alerts.CategoryPrivilegedSessionOpened, 
@@ -849,7 +2267,7 @@ func (m *model) detectAgentChanges(newAgents []Agent) {
 					m.alertManager.AddAlertWithDetails(alerts.AlertInfo, alerts.CategorySessionOpened, 
 						"Beacon upgraded to session", newAgent.Hostname, newAgent.ID, details)
 				}
-			} else if !newAgent.IsSession && oldAgent.IsSession {
+			} else if change.Type {
 				details := "(session→beacon)"
 				m.alertManager.AddAlertWithDetails(alerts.AlertInfo, alerts.CategorySessionClosed, 
 					"Session closed", newAgent.Hostname, newAgent.ID, details)
@@ -858,34 +2276,231 @@ func (m *model) detectAgentChanges(newAgents []Agent) {
 	}
 
 	// Detect beacon task changes (queued/completed)
+	// Task transitions always go to the ops log; acknowledged agents only skip the alert
 	for id, newAgent := range newAgentMap {
 		if !newAgent.IsSession { // Only check beacons
+			acked := m.isAcked(id)
 			if oldAgent, exists := m.previousAgents[id]; exists {
 				// Detect new tasks queued
 				if newAgent.TasksCount > oldAgent.TasksCount {
+					m.opsLog.Add(tracking.OpsTaskQueued, newAgent.ID, newAgent.Hostname, newAgent.TasksCount, newAgent.TasksCompleted)
 					pendingTasks := newAgent.TasksCount - newAgent.TasksCompleted
 					oldPendingTasks := oldAgent.TasksCount - oldAgent.TasksCompleted
 					details := fmt.Sprintf("(%d→%d pending)", oldPendingTasks, pendingTasks)
-					m.alertManager.AddAlertWithDetails(alerts.AlertInfo, alerts.CategoryBeaconTaskQueued, 
-						"Task queued", newAgent.Hostname, newAgent.ID, details)
+					if !acked {
+						m.alertManager.AddAlertWithDetails(alerts.AlertInfo, alerts.CategoryBeaconTaskQueued, 
+							"Task queued", newAgent.Hostname, newAgent.ID, details)
+					}
 				}
 				
 				// Detect tasks completed
 				if newAgent.TasksCompleted > oldAgent.TasksCompleted {
+					tasksCompleted += int(newAgent.TasksCompleted - oldAgent.TasksCompleted)
+					m.opsLog.Add(tracking.OpsTaskCompleted, newAgent.ID, newAgent.Hostname, newAgent.TasksCount, newAgent.TasksCompleted)
 					completedCount := newAgent.TasksCompleted
 					totalCount := newAgent.TasksCount
 					details := fmt.Sprintf("(%d/%d done)", completedCount, totalCount)
-					m.alertManager.AddAlertWithDetails(alerts.AlertSuccess, alerts.CategoryBeaconTaskComplete, 
-						"Task completed", newAgent.Hostname, newAgent.ID, details)
+					if !acked {
+						m.alertManager.AddAlertWithDetails(alerts.AlertSuccess, alerts.CategoryBeaconTaskComplete, 
+							"Task completed", newAgent.Hostname, newAgent.ID, details)
+					}
 				}
 			}
 		}
 	}
 
+	// Fleet-wide privilege: alert only on the >0 → 0 and 0 → >0 transitions.
+	// Agents held in pendingLost still count, so a flapping session inside
+	// the grace period doesn't raise a lost/established pair.
+	privileged := 0
+	var firstPrivileged Agent
+	for _, agent := range newAgents {
+		if agent.IsPrivileged && !agent.IsDead {
+			if firstPrivileged.ID == "" && !m.isAcked(agent.ID) {
+				firstPrivileged = agent
+			}
+			privileged++
+		}
+	}
+	for _, lost := range m.pendingLost {
+		if lost.agent.IsPrivileged && !lost.agent.IsDead {
+			privileged++
+		}
+	}
+	// Acknowledged agents don't raise the fleet alerts either
+	unackedLoss := false
+	for _, agent := range m.previousAgents {
+		if agent.IsPrivileged && !agent.IsDead && !m.isAcked(agent.ID) {
+			unackedLoss = true
+		}
+	}
+	for _, agent := range expiredLost {
+		if agent.IsPrivileged && !agent.IsDead && !m.isAcked(agent.ID) {
+			unackedLoss = true
+		}
+	}
+	if m.prevPrivileged > 0 && privileged == 0 && unackedLoss {
+		m.alertManager.AddAlertWithDetails(alerts.AlertCritical, alerts.CategoryPrivilegeLost,
+			"No privileged access remaining", "fleet", "", fmt.Sprintf("(lost last %d)", m.prevPrivileged))
+	} else if m.prevPrivileged == 0 && privileged > 0 && firstPrivileged.ID != "" {
+		m.alertManager.AddAlertWithDetails(alerts.AlertSuccess, alerts.CategoryPrivilegeRegained,
+			"Privileged foothold established", firstPrivileged.Hostname, firstPrivileged.ID, fmt.Sprintf("(%d privileged)", privileged))
+	}
+	m.prevPrivileged = privileged
+	
+	if m.tempo != nil {
+		m.tempo.Record(tracking.TempoDelta{
+			TasksCompleted: tasksCompleted,
+			Alerts:         m.alertManager.Raised() - raisedBefore,
+		})
+	}
+
+	// Capture the spike between timer samples, at most one per minForcedSampleGap
+	if (notable || m.lastSampleAt.IsZero()) && time.Since(m.lastSampleAt) >= minForcedSampleGap {
+		m.sampleActivity(newAgents, newStats)
+		m.contentDirty = true // Mark for dashboard refresh
+	}
+	
 	// Update previous agents map
 	m.previousAgents = newAgentMap
 }
 
+// taskStallCheckins is how many beacon check-ins must pass with pending
+// tasks growing and none completing before the beacon is reported stalled;
+// a single slow refresh (tasks picked up on one check-in, results on the
+// next) never gets there
+const taskStallCheckins = 3
+
+// taskStall tracks one beacon's run of check-ins without a task completion
+type taskStall struct {
+	completed   int64 // TasksCompleted when the run started
+	pending     int64 // Pending tasks when the run started
+	lastCheckin int64 // LastCheckin last counted
+	checkins    int   // Check-ins seen since the run started
+	alerted     bool  // Stall reported (alert raised, flagged in the task panel)
+}
+
+// trackTaskStalls follows each live beacon's pending tasks across refreshes
+// and raises a warning once tasks keep piling up over several check-ins
+// without any completing (likely a hung or broken implant)
+func (m *model) trackTaskStalls(agents []Agent) {
+	if m.taskStalls == nil {
+		m.taskStalls = make(map[string]*taskStall)
+	}
+	
+	seen := make(map[string]bool)
+	for _, agent := range agents {
+		pending := agent.TasksCount - agent.TasksCompleted
+		if agent.IsSession || agent.IsDead || pending <= 0 {
+			continue
+		}
+		seen[agent.ID] = true
+		
+		stall := m.taskStalls[agent.ID]
+		if stall == nil || agent.TasksCompleted != stall.completed {
+			// First sighting or a task finished: start a new run
+			m.taskStalls[agent.ID] = &taskStall{
+				completed:   agent.TasksCompleted,
+				pending:     pending,
+				lastCheckin: agent.LastCheckin,
+			}
+			continue
+		}
+		
+		if agent.LastCheckin > stall.lastCheckin {
+			stall.checkins++
+			stall.lastCheckin = agent.LastCheckin
+		}
+		if !stall.alerted && stall.checkins >= taskStallCheckins && pending > stall.pending {
+			stall.alerted = true
+			if !m.isAcked(agent.ID) {
+				m.alertManager.AddAlertWithDetails(alerts.AlertWarning, alerts.CategoryBeaconTaskStalled,
+					"Tasks stuck pending", agent.Hostname, agent.ID,
+					fmt.Sprintf("(%d pending, none done in %d check-ins)", pending, stall.checkins))
+			}
+		}
+	}
+	
+	// Runs end when a beacon goes idle, dies, becomes a session or leaves
+	for id := range m.taskStalls {
+		if !seen[id] {
+			delete(m.taskStalls, id)
+		}
+	}
+}
+
+// stalledTasks returns the pending task count of a beacon reported stalled
+// (0 if it isn't)
+func (m model) stalledTasks(agent Agent) int64 {
+	if stall := m.taskStalls[agent.ID]; stall != nil && stall.alerted {
+		return agent.TasksCount - agent.TasksCompleted
+	}
+	return 0
+}
+
+// lostGracePeriod is how long a vanished agent's "lost" alert is held in
+// case it reconnects under the same ID (a few refreshes)
+const lostGracePeriod = 15 * time.Second
+
+// lostAgent is a vanished agent waiting out lostGracePeriod
+type lostAgent struct {
+	agent  Agent
+	lostAt time.Time
+}
+
+// alertLost raises the "lost" alert for a vanished agent, differentiating
+// watched hosts, sessions and beacons
+func (m *model) alertLost(agent Agent) {
+	if m.isWatched(agent) {
+		m.alertManager.AddAlert(alerts.AlertCritical, alerts.CategoryWatchedHostLost, "Watched host lost", agent.Hostname, agent.ID)
+	} else if agent.IsSession {
+		m.alertManager.AddAlert(alerts.AlertCritical, alerts.CategorySessionDisconnected, "Session lost", agent.Hostname, agent.ID)
+	} else {
+		m.alertManager.AddAlert(alerts.AlertCritical, alerts.CategoryBeaconDisconnected, "Beacon lost", agent.Hostname, agent.ID)
+	}
+}
+
+// alertMemberLines wraps a summary alert's member names into lines of at
+// most width cells for its expanded view
+func alertMemberLines(alert alerts.Alert, width int) []string {
+	var lines []string
+	line := ""
+	for i, member := range alert.Members {
+		item := truncateText(member, width)
+		if i < len(alert.Members)-1 {
+			item += ","
+		}
+		switch {
+		case line == "":
+			line = item
+		case lipgloss.Width(line)+1+lipgloss.Width(item) <= width:
+			line += " " + item
+		default:
+			lines = append(lines, line)
+			line = item
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// alertRows maps each content row of the alert panel to the index of the
+// alert drawn there (expanded summaries take extra rows)
+func (m model) alertRows(activeAlerts []alerts.Alert) []int {
+	var rows []int
+	for i, alert := range activeAlerts {
+		rows = append(rows, i)
+		if alert.IsSummary() && alert.ID == m.expandedAlertID {
+			for range alertMemberLines(alert, 64) { // Panel content width less the indent
+				rows = append(rows, i)
+			}
+		}
+	}
+	return rows
+}
+
 // renderAlertPanel renders the military-style alert/notification panel
 func (m model) renderAlertPanel() string {
 	if m.alertManager == nil {
@@ -893,6 +2508,18 @@ func (m model) renderAlertPanel() string {
 	}
 
 	activeAlerts := m.alertManager.GetAlerts()
+	
+	// Quiet mode only surfaces critical alerts
+	if m.isQuiet() {
+		critical := activeAlerts[:0:0]
+		for _, alert := range activeAlerts {
+			if alert.Type == alerts.AlertCritical {
+				critical = append(critical, alert)
+			}
+		}
+		activeAlerts = critical
+	}
+	
 	if len(activeAlerts) == 0 {
 		return "" // No alerts to show
 	}
@@ -971,8 +2598,11 @@ func (m model) renderAlertPanel() string {
 			textColor = m.theme.TacticalMuted // Gray/muted
 		}
 
-		// Format timestamp
+		// Format timestamp (absolute by default, relative when toggled with 'w')
 		timeStr := alert.Timestamp.Format("15:04")
+		if m.prefs != nil && m.prefs.RelativeAlertTimes {
+			timeStr = fmt.Sprintf("%-7s", formatRelativeTime(alert.Timestamp))
+		}
 
 		// Build alert line with military styling
 		icon := alert.GetIcon()
@@ -982,10 +2612,8 @@ func (m model) renderAlertPanel() string {
 			agentName = alert.Message
 		}
 		
-		// Truncate agent name if too long (max 25 chars with expanded panel)
-		if len(agentName) > 25 {
-			agentName = agentName[:25]
-		}
+		// Truncate agent name if too long (max 25 cells with expanded panel)
+		agentName = truncateText(agentName, 25)
 
 		// Pad label to fixed width (28 chars) so hostnames align in a column
 		labelWidth := 28
@@ -1002,6 +2630,22 @@ func (m model) renderAlertPanel() string {
 			alertContent += lipgloss.NewStyle().Foreground(m.theme.TacticalMuted).Render(" " + alert.Details)
 		}
 		
+		// Coalesced bursts preview their first hosts; click to list them all
+		var memberLines []string
+		if alert.IsSummary() {
+			marker := "▸"
+			if alert.ID == m.expandedAlertID {
+				marker = "▾"
+				memberLines = alertMemberLines(alert, contentWidth-4)
+			}
+			preview := alert.Members[0]
+			if len(alert.Members) > 1 {
+				preview += fmt.Sprintf(", +%d", len(alert.Members)-1)
+			}
+			alertContent += lipgloss.NewStyle().Foreground(m.theme.TacticalMuted).Render(
+				" (" + truncateText(preview, 20) + ") " + marker)
+		}
+		
 		// Pad content to fit panel width and add side borders
 		contentLen := lipgloss.Width(alertContent)
 		padding := contentWidth - contentLen
@@ -1011,6 +2655,15 @@ func (m model) renderAlertPanel() string {
 		
 		alertLine := borderStyle.Render("│") + " " + alertContent + strings.Repeat(" ", padding) + " " + borderStyle.Render("│")
 		lines = append(lines, alertLine)
+		
+		for _, member := range memberLines {
+			memberContent := lipgloss.NewStyle().Foreground(m.theme.TacticalMuted).Render("    " + member)
+			padding := contentWidth - lipgloss.Width(memberContent)
+			if padding < 0 {
+				padding = 0
+			}
+			lines = append(lines, borderStyle.Render("│")+" "+memberContent+strings.Repeat(" ", padding)+" "+borderStyle.Render("│"))
+		}
 	}
 	
 	// Bottom border
@@ -1025,6 +2678,11 @@ func (m model) renderAlertPanel() string {
 }
 
 func (m model) View() string {
+	// Terminal too small for the layout - show a clean notice instead of garbage
+	if m.tooSmall {
+		return m.renderTooSmall()
+	}
+	
 	// Show help menu immediately if active (skip all other rendering)
 	if m.showHelp {
 		// Need to use pointer receiver for renderHelpMenu
@@ -1033,6 +2691,28 @@ func (m model) View() string {
 		return (&mPtr).renderHelpMenu()
 	}
 	
+	// Operations log overlay
+	if m.showOpsLog {
+		mPtr := m
+		return (&mPtr).renderOpsLog()
+	}
+	
+	// Raw field inspector overlay
+	if m.inspectAgentID != "" {
+		mPtr := m
+		return (&mPtr).renderScrollOverlay("I/ESC: close")
+	}
+	
+	// Compound filter picker
+	if m.showFilterPicker {
+		return m.renderFilterPicker()
+	}
+	
+	// Theme picker
+	if m.showThemePicker {
+		return m.renderThemePicker()
+	}
+	
 	// Build header (title + status) - this is FIXED at top, not scrollable
 	var headerLines []string
 	titleStyle := lipgloss.NewStyle().
@@ -1041,6 +2721,18 @@ func (m model) View() string {
 		Background(m.theme.HeaderBg).
 		Padding(0, 1)
 	title := titleStyle.Render("🎯 Sliver C2 TUI")
+	if m.demoFleet != nil {
+		title += lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#000000")).
+			Background(m.theme.LogoColor).
+			Bold(true).
+			Padding(0, 1).
+			Render("DEMO DATA")
+	}
+	title += m.renderTempoBadge()
+	if strip := m.renderSubnetHeatStrip(m.termWidth - lipgloss.Width(title) - 2); strip != "" {
+		title += "  " + strip
+	}
 	headerLines = append(headerLines, title)
 	
 	statusStyle := lipgloss.NewStyle().
@@ -1049,21 +2741,70 @@ func (m model) View() string {
 		Italic(true).
 		MarginBottom(1).
 		Padding(0, 1)
-	statusText := fmt.Sprintf("Last Update: %s", m.lastUpdate.Format("15:04:05"))
-	if m.ready && len(m.agents) > 0 {
+	// Last Update turns yellow, then red, as the data ages past the stale
+	// threshold (re-rendered every animation tick, so the age keeps counting)
+	updateStyle := statusStyle.PaddingRight(0)
+	updateText := fmt.Sprintf("Last Update: %s", m.lastUpdate.Format("15:04:05"))
+	if threshold := m.staleThreshold(); threshold > 0 && !m.lastUpdate.IsZero() {
+		if age := time.Since(m.lastUpdate); age >= threshold {
+			updateText += " (" + formatRelativeTime(m.lastUpdate) + ")"
+			updateStyle = updateStyle.Foreground(lipgloss.Color("#f1fa8c")).Bold(true)
+			if age >= staleCriticalFactor*threshold {
+				updateStyle = updateStyle.Foreground(lipgloss.Color("#ff5555"))
+			}
+		}
+	}
+	statusText := ""
+	if !m.connectedAt.IsZero() {
+		statusText += fmt.Sprintf("  │  Engaged: %s", formatDuration(time.Since(m.connectedAt)))
+	}
+	// Debug fields stay on the same status line, so hiding them never
+	// changes the header height the viewport math depends on
+	headerDebug := config.HeaderDebugScroll
+	if m.prefs != nil && m.prefs.HeaderDebug != "" {
+		headerDebug = m.prefs.HeaderDebug
+	}
+	if m.ready && len(m.agents) > 0 && !m.isQuiet() && headerDebug != config.HeaderDebugNone {
 		scrollPercent := int(m.viewport.ScrollPercent() * 100)
 		statusText += fmt.Sprintf("  │  Scroll: %d%%", scrollPercent)
 	}
-	if m.termWidth > 0 && m.termHeight > 0 {
+	if m.termWidth > 0 && m.termHeight > 0 && !m.isQuiet() && headerDebug == config.HeaderDebugAll {
 		statusText += fmt.Sprintf("  │  Term: %dx%d", m.termWidth, m.termHeight)
 	}
 	iconStyleName := "Nerd Font"
 	if m.iconStyle == IconStyleEmoji {
 		iconStyleName = "Emoji"
 	}
-	statusText += fmt.Sprintf("  │  Theme: %s  │  View: %s  │  Icons: %s", m.theme.Name, m.view.Name, iconStyleName)
-	headerLines = append(headerLines, statusStyle.Render(statusText))
-	headerLines = append(headerLines, "")
+	statusText += fmt.Sprintf("  │  Theme: %s  │  View: %s  │  Icons: %s", m.theme.Name, m.viewLabel(), iconStyleName)
+	if summary, _ := m.baselineProgress(); summary != "" {
+		statusText += fmt.Sprintf("  │  Scope: %s", summary)
+	}
+	if m.operators != nil {
+		statusText += fmt.Sprintf("  │  Operators: %s", formatOperators(m.operators))
+	}
+	if len(m.hiddenAgents) > 0 {
+		statusText += fmt.Sprintf("  │  Hidden: %d (X unhides)", len(m.hiddenAgents))
+	}
+	headerLines = append(headerLines, lipgloss.JoinHorizontal(lipgloss.Top,
+		updateStyle.Render(updateText), statusStyle.PaddingLeft(0).Render(statusText)))
+	
+	// Failed refresh warning (takes the spacer line so the layout height holds)
+	if m.err != nil {
+		warningStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#000000")).
+			Background(lipgloss.Color("#ff9900")).
+			Bold(true).
+			Padding(0, 1)
+		warningText := fmt.Sprintf("⚠ Refresh failed: %v", m.err)
+		if !m.lastUpdate.IsZero() {
+			warningText = fmt.Sprintf("⚠ Last refresh failed - showing stale data from %s: %v",
+				m.lastUpdate.Format("15:04:05"), m.err)
+		}
+		warningText = strings.ReplaceAll(warningText, "\n", " ") // gRPC errors can span lines
+		headerLines = append(headerLines, warningStyle.Render(truncateText(warningText, max(m.termWidth-2, 20))))
+	} else {
+		headerLines = append(headerLines, "")
+	}
 	
 	// Build scrollable content area (agents)
 	var contentLines []string
@@ -1152,30 +2893,10 @@ func (m model) View() string {
 		separatorStyle.Render("┐"))
 	footerLines = append(footerLines, topBorder)
 	
-	// Line 1: Stats (with optional "Recently Lost" inline and vertical borders)
-	lostCount := tracking.GetLostAgentsCount()
-	
-	// Build styled content directly (don't calculate width on plain text with emojis)
+	// Line 1: Stats (with optional "Recently Lost" inline and vertical borders),
+	// compacted to fit between the borders
 	borderStyle := lipgloss.NewStyle().Foreground(m.theme.SeparatorColor)
-	
-	var styledStatsContent string
-	if lostCount > 0 {
-		// Apply colors to each section
-		sessionsText := lipgloss.NewStyle().Foreground(m.theme.StatsColor).Bold(true).Render(fmt.Sprintf("🟢 Sessions: %d", m.stats.Sessions))
-		beaconsText := lipgloss.NewStyle().Foreground(m.theme.StatsColor).Bold(true).Render(fmt.Sprintf("🟡 Beacons: %d", m.stats.Beacons))
-		totalText := lipgloss.NewStyle().Foreground(m.theme.StatsColor).Bold(true).Render(fmt.Sprintf("🔵 Total: %d", m.stats.Compromised))
-		lostText := lipgloss.NewStyle().Foreground(lipgloss.Color("#ff9900")).Bold(true).Render(fmt.Sprintf("⚠️  Lost: %d (tracking %dm)", lostCount, int(tracking.GetLostAgentTimeout().Minutes())))
-		
-		styledStatsContent = fmt.Sprintf("%s  │  %s  │  %s  │  %s",
-			sessionsText, beaconsText, totalText, lostText)
-	} else {
-		sessionsText := lipgloss.NewStyle().Foreground(m.theme.StatsColor).Bold(true).Render(fmt.Sprintf("🟢 Sessions: %d", m.stats.Sessions))
-		beaconsText := lipgloss.NewStyle().Foreground(m.theme.StatsColor).Bold(true).Render(fmt.Sprintf("🟡 Beacons: %d", m.stats.Beacons))
-		totalText := lipgloss.NewStyle().Foreground(m.theme.StatsColor).Bold(true).Render(fmt.Sprintf("🔵 Total: %d", m.stats.Compromised))
-		
-		styledStatsContent = fmt.Sprintf("%s  │  %s  │  %s",
-			sessionsText, beaconsText, totalText)
-	}
+	styledStatsContent := m.renderFooterStats(separatorWidth - 4)
 	
 	// Use lipgloss.Width to get actual rendered width (handles ANSI codes properly)
 	contentWidth := lipgloss.Width(styledStatsContent)
@@ -1203,17 +2924,66 @@ func (m model) View() string {
 		separatorStyle.Render("┘"))
 	footerLines = append(footerLines, bottomBorder)
 	
-	// Line 2: Help shortcuts (more concise format)
-	helpText := "[r] Refresh  [t] Theme  [i] Icons  [v] View  [d] Dashboard  [e] Expand  [#] Subnet  [↑↓] Scroll  [q] Quit"
-	helpStyle := lipgloss.NewStyle().
-		Foreground(m.theme.HelpColor).
-		Width(separatorWidth).
-		Align(lipgloss.Left).
-		Padding(0, 1)
-	footerLines = append(footerLines, helpStyle.Render(helpText))
+	// Transport color legend ('K')
+	if m.showLegend {
+		footerLines = append(footerLines, " "+m.renderTransportLegend())
+	}
+	
+	// Line 2: Help shortcuts (more concise format) - hidden in quiet mode
+	if !m.isQuiet() {
+		helpText := "[r] Refresh  [t] Theme  [i] Icons  [v] View  [d] Dashboard  [e] Expand  [#] Subnet  [↑↓] Scroll  [q] Quit"
+		helpStyle := lipgloss.NewStyle().
+			Foreground(m.theme.HelpColor).
+			Width(separatorWidth).
+			Align(lipgloss.Left).
+			Padding(0, 1)
+		footerLines = append(footerLines, helpStyle.Render(helpText))
+		
+		// Bottom spacing
+		footerLines = append(footerLines, "")
+	}
+	
+	// First blood banner (one-time, dismissed with Esc)
+	if m.firstBloodHost != "" {
+		bannerStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#000000")).
+			Background(lipgloss.Color("#ff5555")).
+			Bold(true).
+			Padding(0, 1)
+		bannerText := fmt.Sprintf("🩸 FIRST BLOOD - First agent acquired: %s  (Esc to dismiss)", m.firstBloodHost)
+		footerLines = append(footerLines, bannerStyle.Render(bannerText))
+		footerLines = append(footerLines, "") // Add empty line for spacing
+	}
+	
+	// Search prompt / match counter
+	if m.searchMode || m.searchQuery != "" {
+		searchStyle := lipgloss.NewStyle().
+			Foreground(m.theme.TitleColor).
+			Bold(true).
+			Padding(0, 1)
+		var searchText string
+		if m.searchMode {
+			searchText = fmt.Sprintf("/%s_ (Enter to search, Esc to cancel)", m.searchQuery)
+		} else if len(m.searchMatches) == 0 {
+			searchText = fmt.Sprintf("🔍 \"%s\": no matches (Esc to clear)", m.searchQuery)
+		} else {
+			searchText = fmt.Sprintf("🔍 \"%s\": match %d of %d (n/N next/prev, Esc to clear)",
+				m.searchQuery, m.searchIndex+1, len(m.searchMatches))
+		}
+		footerLines = append(footerLines, searchStyle.Render(searchText))
+		footerLines = append(footerLines, "") // Add empty line for spacing
+	}
 	
-	// Bottom spacing
-	footerLines = append(footerLines, "")
+	// Server switch confirmation
+	if m.pendingServer != "" {
+		confirmStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#f1fa8c")). // Yellow
+			Bold(true).
+			Padding(0, 1)
+		confirmText := fmt.Sprintf("⇄ Switch server to %s? Agents, alerts and history will be reset (y/n)", filepath.Base(m.pendingServer))
+		footerLines = append(footerLines, confirmStyle.Render(confirmText))
+		footerLines = append(footerLines, "") // Add empty line for spacing
+	}
 	
 	// Show number buffer indicator if user is typing a subnet number (separate line)
 	if len(m.numberBuffer) > 0 {
@@ -1221,7 +2991,7 @@ func (m model) View() string {
 			Foreground(lipgloss.Color("#f1fa8c")). // Yellow
 			Bold(true).
 			Padding(0, 1)
-		bufferText := fmt.Sprintf("> Subnet #%s_ (press Enter to toggle, Esc to cancel)", m.numberBuffer)
+		bufferText := fmt.Sprintf("> Subnet #%s_ (Enter: toggle, o: focus, >: drill down, Esc: cancel)", m.numberBuffer)
 		footerLines = append(footerLines, bufferStyle.Render(bufferText))
 		footerLines = append(footerLines, "") // Add empty line for spacing
 	}
@@ -1268,10 +3038,29 @@ func (m model) View() string {
 			panelStartLine = headerLineCount
 		}
 		
-		// Ensure we have enough lines for the panel
+		// Short content (a few agents): add blank lines between the content
+		// and footer so the left column reaches the panel's bottom and the
+		// footer sits under it, but never past the screen bottom - the
+		// renderer would drop the top lines, clipping the header and panel
+		targetLines := panelStartLine + len(panelLines)
+		if m.termHeight > 0 && targetLines > m.termHeight {
+			targetLines = m.termHeight
+		}
+		if missing := targetLines - len(leftLines); missing > 0 {
+			footerLineCount := 0
+			if len(footerLines) > 0 {
+				footerLineCount = len(strings.Split(strings.Join(footerLines, "\n"), "\n"))
+			}
+			footerStart := max(len(leftLines)-footerLineCount, panelStartLine)
+			padded := append([]string{}, leftLines[:footerStart]...)
+			padded = append(padded, make([]string, missing)...)
+			leftLines = append(padded, leftLines[footerStart:]...)
+		}
+		
+		// A panel taller than the screen is cut at the bottom instead
 		totalLines := len(leftLines)
-		if panelStartLine + len(panelLines) > totalLines {
-			totalLines = panelStartLine + len(panelLines)
+		if visible := totalLines - panelStartLine; len(panelLines) > visible && visible > 0 {
+			panelLines = panelLines[:visible]
 		}
 		
 		// Build output by overlaying panel on right side
@@ -1370,8 +3159,8 @@ func (m model) View() string {
 			if m.alertManager != nil {
 				activeAlerts := m.alertManager.GetAlerts()
 				alertContentStartLine := alertStartLine + 1
-				for i, alert := range activeAlerts {
-					if alert.AgentID != "" {
+				for i, index := range m.alertRows(activeAlerts) {
+					if alert := activeAlerts[index]; alert.AgentID != "" {
 						m.alertLineMap[alertContentStartLine+i] = alert.AgentID
 					}
 				}
@@ -1414,30 +3203,703 @@ func (m model) View() string {
 	return leftContent
 }
 
-// renderAgentDetailsPanel renders detailed information about the selected agent
-func (m model) renderAgentDetailsPanel() string {
-	// Only show if an agent is selected
-	if m.selectedAgentID == "" {
-		return ""
+// handleSearchKey handles keys while the search prompt is open
+func (m model) handleSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEsc:
+		m.searchMode = false
+		m.searchQuery = ""
+		m.searchMatches = nil
+	case tea.KeyEnter:
+		m.searchMode = false
+		m.searchQuery = strings.TrimSpace(m.searchQuery)
+		m.searchIndex = 0
+		m.updateSearchMatches()
+		if len(m.searchMatches) > 0 {
+			m.jumpToSearchMatch()
+		}
+	case tea.KeyBackspace:
+		if len(m.searchQuery) > 0 {
+			runes := []rune(m.searchQuery)
+			m.searchQuery = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.searchQuery += string(msg.Runes)
+	}
+	m.fitViewport() // The search footer may have closed
+	return m, nil
+}
+
+// statFlashDuration is how many animation ticks (150ms each) a changed
+// footer count stays highlighted
+const statFlashDuration = 8
+
+// renderFooterStat renders a footer count, flashing it green (increase) or
+// red (decrease) while the change flash is running. The flash fades to a
+// faint tint over its last ticks.
+func (m model) renderFooterStat(text string, current, previous int) string {
+	style := lipgloss.NewStyle().Foreground(m.theme.StatsColor).Bold(true)
+	if m.statFlashTicks > 0 && current != previous {
+		if current > previous {
+			style = style.Foreground(lipgloss.Color("#50fa7b"))
+		} else {
+			style = style.Foreground(lipgloss.Color("#ff5555"))
+		}
+		if m.statFlashTicks <= statFlashDuration/3 {
+			style = style.Faint(true)
+		}
 	}
-	
-	// Find the selected agent
-	var selectedAgent *Agent
-	for i := range m.agents {
-		if m.agents[i].ID == m.selectedAgentID {
-			selectedAgent = &m.agents[i]
-			break
+	return style.Render(text)
+}
+
+// footerStatsLevels is how many compaction levels footerStatsContent has
+const footerStatsLevels = 5
+
+// renderFooterStats renders the footer stats line content in at most width
+// cells. When the full line doesn't fit it is compacted a step at a time:
+// the lost tracking note goes first, then the trend arrows, the count
+// labels (icons only) and the filter/pending notes; whatever still
+// overflows is cut.
+func (m model) renderFooterStats(width int) string {
+	var content string
+	for level := 0; level < footerStatsLevels; level++ {
+		content = m.footerStatsContent(level)
+		if lipgloss.Width(content) <= width {
+			return content
 		}
 	}
-	
-	if selectedAgent == nil {
-		return "" // Agent not found
+	return ansi.Truncate(content, width, "…")
+}
+
+// footerStatsContent builds the footer stats line at a compaction level
+// (0 = full, see renderFooterStats)
+func (m model) footerStatsContent(level int) string {
+	// Apply colors to each section (changed counts flash briefly)
+	stat := func(icon, label string, current, previous int) string {
+		if level >= 3 {
+			return m.renderFooterStat(fmt.Sprintf("%s %d", icon, current), current, previous)
+		}
+		return m.renderFooterStat(fmt.Sprintf("%s %s: %d", icon, label, current), current, previous)
+	}
+	trend := func(count func(Stats) int) string {
+		if level >= 2 {
+			return ""
+		}
+		return m.renderStatTrend(count)
+	}
+	segments := []string{
+		stat("🟢", "Sessions", m.stats.Sessions, m.prevStats.Sessions) +
+			trend(func(s Stats) int { return s.Sessions }),
+		stat("🟡", "Beacons", m.stats.Beacons, m.prevStats.Beacons) +
+			trend(func(s Stats) int { return s.Beacons }),
+		stat("💎", "Privileged", m.stats.Privileged, m.prevStats.Privileged) +
+			trend(func(s Stats) int { return s.Privileged }),
+		stat("🔵", "Total", m.stats.Compromised, m.prevStats.Compromised),
+	}
+	if level < 4 {
+		if m.prefs != nil && m.prefs.AgentFilter.IsActive() {
+			segments = append(segments, lipgloss.NewStyle().Foreground(m.theme.TitleColor).Bold(true).
+				Render(fmt.Sprintf("🔎 %s", m.prefs.AgentFilter)))
+		}
+		if m.prefs != nil && m.prefs.HideIncomplete {
+			if pending := countIncomplete(m.allAgents); pending > 0 {
+				segments = append(segments, lipgloss.NewStyle().Foreground(m.theme.TacticalMuted).
+					Render(fmt.Sprintf("◌ %d pending", pending)))
+			}
+		}
+	}
+	if lostCount := m.tracker.GetLostAgentsCount(); lostCount > 0 {
+		lostText := fmt.Sprintf("⚠️  Lost: %d", lostCount)
+		if level == 0 {
+			lostText += fmt.Sprintf(" (tracking %dm)", int(m.tracker.GetLostAgentTimeout().Minutes()))
+		}
+		segments = append(segments, lipgloss.NewStyle().Foreground(lipgloss.Color("#ff9900")).Bold(true).Render(lostText))
+	}
+	return strings.Join(segments, "  │  ")
+}
+
+// statsHistoryLen is how many fetches the trend history keeps, the current
+// one included, so the footer trend arrows compare with statsHistoryLen-1
+// fetches ago
+const statsHistoryLen = 6
+
+// recordStatsHistory appends the current stats to the trend history
+func (m *model) recordStatsHistory() {
+	m.statsHistory = append(m.statsHistory, m.stats)
+	if len(m.statsHistory) > statsHistoryLen {
+		m.statsHistory = m.statsHistory[len(m.statsHistory)-statsHistoryLen:]
+	}
+}
+
+// renderStatTrend renders an arrow comparing a footer count with the oldest
+// value in the trend history (statsHistoryLen-1 fetches ago once full):
+// ↑ higher, ↓ lower, → unchanged ("" until there's history to compare against)
+func (m model) renderStatTrend(count func(Stats) int) string {
+	if len(m.statsHistory) < 2 {
+		return ""
+	}
+	current, past := count(m.stats), count(m.statsHistory[0])
+	switch {
+	case current > past:
+		return " " + lipgloss.NewStyle().Foreground(m.theme.SessionColor).Bold(true).Render("↑")
+	case current < past:
+		return " " + lipgloss.NewStyle().Foreground(m.theme.DeadColor).Bold(true).Render("↓")
+	default:
+		return " " + lipgloss.NewStyle().Foreground(m.theme.TacticalMuted).Render("→")
+	}
+}
+
+// filterAgents applies the persisted compound filter (and, with
+// HideIncomplete, holds back agents still missing fields) to the last fetch,
+// returning the visible agents and stats recomputed over them
+func (m model) filterAgents() ([]Agent, Stats) {
+	if m.prefs == nil || (!m.prefs.AgentFilter.IsActive() && !m.prefs.HideIncomplete && !m.prefs.CountHosts && len(m.hiddenAgents) == 0) {
+		return m.allAgents, m.allStats
 	}
 	
-	panelStyle := lipgloss.NewStyle().
-		Border(lipgloss.ThickBorder()).
-		BorderForeground(m.theme.TitleColor).
-		Background(lipgloss.Color("#1a1a2e")).
+	var agents []Agent
+	for _, agent := range m.allAgents {
+		if !m.prefs.AgentFilter.Matches(agent) || (m.prefs.HideIncomplete && agent.Incomplete) || m.hiddenAgents[agent.ID] {
+			continue
+		}
+		agents = append(agents, agent)
+	}
+	return agents, m.countStats(agents)
+}
+
+// countHosts reports whether displayed totals count unique hosts rather
+// than agent connections (CountHosts pref)
+func (m model) countHosts() bool {
+	return m.prefs != nil && m.prefs.CountHosts
+}
+
+// countKey returns what an agent counts as in displayed totals: itself
+// (one per implant connection) or, when counting hosts, its hostname, so
+// several agents on one machine count once
+func (m model) countKey(agent Agent) string {
+	if m.countHosts() {
+		return strings.ToLower(agent.Hostname)
+	}
+	return agent.ID
+}
+
+// countOf counts the agents match accepts, as agents or unique hosts
+// depending on the count mode
+func (m model) countOf(agents []Agent, match func(Agent) bool) int {
+	seen := make(map[string]bool)
+	for _, agent := range agents {
+		if match(agent) {
+			seen[m.countKey(agent)] = true
+		}
+	}
+	return len(seen)
+}
+
+// countUnit returns the noun for n counted things ("agent(s)" or "host(s)")
+func (m model) countUnit(n int) string {
+	unit := "agent"
+	if m.countHosts() {
+		unit = "host"
+	}
+	if n != 1 {
+		unit += "s"
+	}
+	return unit
+}
+
+// countStats computes the footer stats for agents in the count mode. When
+// counting hosts, a host with both a session and a beacon counts toward both.
+func (m model) countStats(agents []Agent) Stats {
+	hosts := make(map[string]bool)
+	for _, agent := range agents {
+		hosts[strings.ToLower(agent.Hostname)] = true
+	}
+	return Stats{
+		Sessions:    m.countOf(agents, func(agent Agent) bool { return agent.IsSession }),
+		Beacons:     m.countOf(agents, func(agent Agent) bool { return !agent.IsSession }),
+		Privileged:  m.countOf(agents, func(agent Agent) bool { return agent.IsPrivileged }),
+		Hosts:       len(hosts),
+		Compromised: m.countOf(agents, func(Agent) bool { return true }),
+	}
+}
+
+// setAgentFilter activates and persists a compound filter, re-filtering the
+// current agents across every view
+func (m *model) setAgentFilter(filter config.AgentFilter) {
+	if m.prefs == nil {
+		return
+	}
+	m.prefs.AgentFilter = filter
+	m.savePrefs()
+	m.showFilterPicker = false
+	m.refilterAgents()
+}
+
+// refilterAgents re-applies the filter, incomplete/hidden agents and count
+// mode to the last fetch, updating every view
+func (m *model) refilterAgents() {
+	m.agents, m.stats = m.filterAgents()
+	m.prevStats = m.stats // Switching filters isn't a fleet change; don't flash
+	m.statsHistory = nil  // ...or a trend
+	m.recordStatsHistory()
+	m.updateSubnetOrder()
+	m.contentDirty = true
+	if m.ready {
+		m.updateViewportContent()
+	}
+	m.updateSearchMatches()
+}
+
+// renderFilterPicker draws the compound filter overlay, centered
+func (m model) renderFilterPicker() string {
+	titleStyle := lipgloss.NewStyle().Foreground(m.theme.TitleColor).Bold(true)
+	itemStyle := lipgloss.NewStyle().Foreground(m.theme.TacticalValue)
+	cursorStyle := lipgloss.NewStyle().Foreground(m.theme.TitleColor).Bold(true).Reverse(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(m.theme.TacticalMuted)
+	
+	var lines []string
+	lines = append(lines, titleStyle.Render("🔎 FILTER AGENTS"))
+	lines = append(lines, "")
+	for i, preset := range config.AgentFilterPresets() {
+		marker := "  "
+		if m.prefs != nil && preset == m.prefs.AgentFilter {
+			marker = "● "
+		}
+		item := fmt.Sprintf("%d  %s%s", i+1, marker, preset)
+		if i == m.filterCursor {
+			lines = append(lines, cursorStyle.Render(padText(item, 28)))
+		} else {
+			lines = append(lines, itemStyle.Render(padText(item, 28)))
+		}
+	}
+	lines = append(lines, "")
+	lines = append(lines, mutedStyle.Render("↑↓/jk move • Enter/1-9 pick • F/Esc close"))
+	
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.theme.TitleColor).
+		Padding(1, 2).
+		Render(strings.Join(lines, "\n"))
+	return lipgloss.Place(m.termWidth, m.termHeight, lipgloss.Center, lipgloss.Center, box)
+}
+
+// setTheme switches to the theme at index and records it as most recently
+// used in the prefs
+func (m *model) setTheme(index int) {
+	m.themeIndex = index
+	m.theme = config.GetTheme(index)
+	if m.prefs != nil {
+		m.prefs.UseTheme(index)
+		m.savePrefs()
+	}
+	m.contentDirty = true
+	// Update viewport content with new theme
+	if m.ready {
+		m.updateViewportContent()
+	}
+}
+
+// themePickerOrder lists theme indices for the picker: recently used first
+// (most recent at the top), then the rest in cycle order
+func (m model) themePickerOrder() []int {
+	var order []int
+	listed := make(map[int]bool)
+	if m.prefs != nil {
+		for _, i := range m.prefs.RecentThemes {
+			order = append(order, i)
+			listed[i] = true
+		}
+	}
+	for i := 0; i < config.GetThemeCount(); i++ {
+		if !listed[i] {
+			order = append(order, i)
+		}
+	}
+	return order
+}
+
+// renderThemePicker draws the theme picker overlay, centered
+func (m model) renderThemePicker() string {
+	titleStyle := lipgloss.NewStyle().Foreground(m.theme.TitleColor).Bold(true)
+	itemStyle := lipgloss.NewStyle().Foreground(m.theme.TacticalValue)
+	recentStyle := lipgloss.NewStyle().Foreground(m.theme.TitleColor)
+	cursorStyle := lipgloss.NewStyle().Foreground(m.theme.TitleColor).Bold(true).Reverse(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(m.theme.TacticalMuted)
+	
+	recent := 0
+	if m.prefs != nil {
+		recent = len(m.prefs.RecentThemes)
+	}
+	
+	var lines []string
+	lines = append(lines, titleStyle.Render("🎨 THEMES"))
+	lines = append(lines, "")
+	for row, index := range m.themePickerOrder() {
+		if row == recent && recent > 0 {
+			lines = append(lines, mutedStyle.Render(strings.Repeat("─", 28)))
+		}
+		marker := "  "
+		if index == m.themeIndex {
+			marker = "● "
+		} else if row < recent {
+			marker = "★ "
+		}
+		item := padText(marker+config.GetTheme(index).Name, 28)
+		switch {
+		case row == m.themeCursor:
+			lines = append(lines, cursorStyle.Render(item))
+		case row < recent:
+			lines = append(lines, recentStyle.Render(item))
+		default:
+			lines = append(lines, itemStyle.Render(item))
+		}
+	}
+	lines = append(lines, "")
+	lines = append(lines, mutedStyle.Render("↑↓/jk move • Enter pick • Alt+T/Esc close"))
+	
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.theme.TitleColor).
+		Padding(1, 2).
+		Render(strings.Join(lines, "\n"))
+	return lipgloss.Place(m.termWidth, m.termHeight, lipgloss.Center, lipgloss.Center, box)
+}
+
+// fleetTitle builds the terminal title, e.g. "Sliver: 12S 30B (2 crit)"
+func (m model) fleetTitle() string {
+	title := fmt.Sprintf("Sliver: %dS %dB", m.allStats.Sessions, m.allStats.Beacons)
+	if m.alertManager != nil {
+		if critical := m.alertManager.CountCritical(); critical > 0 {
+			title += fmt.Sprintf(" (%d crit)", critical)
+		}
+	}
+	return title
+}
+
+// updateWindowTitle returns a command setting the terminal title when the
+// WindowTitle pref is on and the title changed (nil otherwise)
+func (m *model) updateWindowTitle() tea.Cmd {
+	if m.prefs == nil || !m.prefs.WindowTitle {
+		return nil
+	}
+	title := m.fleetTitle()
+	if title == m.windowTitle {
+		return nil
+	}
+	m.windowTitle = title
+	return tea.SetWindowTitle(title)
+}
+
+// writeSnapshot saves a rendered screen to the current directory twice: with
+// its ANSI styling (replay with `cat`) and stripped to plain text. Returns
+// both paths.
+func writeSnapshot(screen string) (string, string, error) {
+	stamp := time.Now().Format("20060102-150405")
+	ansiPath := fmt.Sprintf("sliver-tui-snapshot-%s.ansi.txt", stamp)
+	plainPath := fmt.Sprintf("sliver-tui-snapshot-%s.txt", stamp)
+	
+	if err := os.WriteFile(ansiPath, []byte(screen+"\n"), 0600); err != nil {
+		return "", "", fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := os.WriteFile(plainPath, []byte(ansi.Strip(screen)+"\n"), 0600); err != nil {
+		return "", "", fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return ansiPath, plainPath, nil
+}
+
+// formatOperators summarizes the online operators for the header, e.g.
+// "3 (alice, bob, +1)"
+func formatOperators(names []string) string {
+	const maxNames = 2
+	sorted := append([]string(nil), names...)
+	sort.Strings(sorted)
+	if len(sorted) == 0 {
+		return "0"
+	}
+	shown := sorted
+	if len(shown) > maxNames {
+		shown = append(shown[:maxNames:maxNames], fmt.Sprintf("+%d", len(sorted)-maxNames))
+	}
+	return fmt.Sprintf("%d (%s)", len(sorted), strings.Join(shown, ", "))
+}
+
+// collectIPs returns the unique agent IPs (ports stripped) of the agents
+// keep accepts, sorted numerically; unparsable addresses sort last
+func collectIPs(agents []Agent, keep func(Agent) bool) []string {
+	seen := make(map[string]bool)
+	var ips []string
+	for _, agent := range agents {
+		if agent.RemoteAddress == "" || !keep(agent) {
+			continue
+		}
+		ip := agentIP(agent.RemoteAddress)
+		if !seen[ip] {
+			seen[ip] = true
+			ips = append(ips, ip)
+		}
+	}
+	
+	sort.Slice(ips, func(i, j int) bool {
+		a, errA := netip.ParseAddr(ips[i])
+		b, errB := netip.ParseAddr(ips[j])
+		switch {
+		case errA == nil && errB == nil:
+			return a.Less(b)
+		case errA == nil || errB == nil:
+			return errA == nil // Valid addresses first
+		default:
+			return ips[i] < ips[j]
+		}
+	})
+	return ips
+}
+
+// writeIPList writes ips one per line to sliver-tui-ips-<scope>-<stamp>.txt
+// in the working directory and returns the path
+func writeIPList(ips []string, scope string) (string, error) {
+	path := fmt.Sprintf("sliver-tui-ips-%s-%s.txt", scope, time.Now().Format("20060102-150405"))
+	if err := os.WriteFile(path, []byte(strings.Join(ips, "\n")+"\n"), 0600); err != nil {
+		return "", fmt.Errorf("failed to write IP list: %w", err)
+	}
+	return path, nil
+}
+
+// clipboardCmd puts text on the system clipboard via the OSC 52 escape
+// sequence (works over SSH; terminals without support ignore it)
+func clipboardCmd(text string) tea.Cmd {
+	return func() tea.Msg {
+		fmt.Fprintf(termOutput, "\x1b]52;c;%s\x07", base64.StdEncoding.EncodeToString([]byte(text)))
+		return nil
+	}
+}
+
+// termOutput is the program's output. Writes are serialized so escape
+// sequences sent outside the renderer (OSC 52) can't land mid-frame.
+var termOutput = &lockedFile{File: os.Stdout}
+
+// lockedFile is an *os.File (so bubbletea still sees a TTY) whose writes
+// hold a mutex
+type lockedFile struct {
+	*os.File
+	mu sync.Mutex
+}
+
+func (f *lockedFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.File.Write(p)
+}
+
+func (f *lockedFile) WriteString(s string) (int, error) {
+	return f.Write([]byte(s))
+}
+
+// resetServerState drops everything learned from the current server so a
+// newly selected server starts clean (agents, change tracking, caches,
+// alerts and activity history)
+func (m *model) resetServerState() {
+	m.agents = nil
+	m.stats = Stats{}
+	m.allAgents = nil
+	m.allStats = Stats{}
+	m.prevStats = Stats{}
+	m.statsHistory = nil
+	m.statFlashTicks = 0
+	m.previousAgents = make(map[string]Agent)
+	m.domainCache = newDomainCache()
+	m.domainQueries = make(map[string]bool)
+	m.dnsCache = newDNSCache()
+	m.dnsLookups = make(map[string]bool)
+	m.ackedAgents = make(map[string]time.Time)
+	m.taskStalls = nil
+	m.pendingLost = nil
+	m.prevPrivileged = -1
+	m.lastSampleAt = time.Time{}
+	m.hiddenAgents = make(map[string]bool)
+	m.duplicatePIDs = nil
+	m.sharedEgress = nil
+	m.operators = nil
+	m.connectedAt = time.Time{}
+	m.modalVersion = ""
+	m.skewedVersions = nil
+	m.activityTracker = NewActivityTracker()
+	m.sparklineCache = SparklineCache{}
+	m.opsLog = tracking.NewOpsLog(500)
+	m.tempo = tracking.NewTempoTracker()
+	m.alertManager.ClearAll()
+	m.tracker = tracking.NewTracker() // In-flight fetches keep writing to the old one
+	
+	m.selectedAgentID = ""
+	m.searchQuery = ""
+	m.searchMatches = nil
+	m.subnetOrder = nil
+	m.drillSubnet = ""
+	m.contentDirty = true
+	m.fitViewport()
+	if m.ready {
+		m.updateViewportContent()
+	}
+}
+
+// agentMatchesQuery reports whether an agent matches a search query
+// (case-insensitive substring of hostname, user, ID, IP, OS or transport)
+func agentMatchesQuery(agent Agent, query string) bool {
+	query = strings.ToLower(query)
+	for _, field := range []string{agent.Hostname, agent.Username, agent.ID, agent.RemoteAddress, agent.OS, agent.Transport} {
+		if strings.Contains(strings.ToLower(field), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// updateSearchMatches recomputes the search matches in display order
+// (by viewport line where known, then by hostname), keeping the current
+// match selected if it still matches
+func (m *model) updateSearchMatches() {
+	if m.searchQuery == "" {
+		m.searchMatches = nil
+		return
+	}
+	
+	current := ""
+	if m.searchIndex < len(m.searchMatches) {
+		current = m.searchMatches[m.searchIndex]
+	}
+	
+	// First viewport line of each agent
+	agentLines := make(map[string]int)
+	for line, agentID := range m.agentLineMap {
+		if existing, ok := agentLines[agentID]; !ok || line < existing {
+			agentLines[agentID] = line
+		}
+	}
+	
+	var matches []Agent
+	for _, agent := range m.flattenAgents(m.agents) {
+		if agentMatchesQuery(agent, m.searchQuery) {
+			matches = append(matches, agent)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		lineI, okI := agentLines[matches[i].ID]
+		lineJ, okJ := agentLines[matches[j].ID]
+		if okI != okJ {
+			return okI // Visible agents first
+		}
+		if okI && lineI != lineJ {
+			return lineI < lineJ
+		}
+		return matches[i].Hostname < matches[j].Hostname
+	})
+	
+	m.searchMatches = make([]string, 0, len(matches))
+	m.searchIndex = 0
+	for i, agent := range matches {
+		m.searchMatches = append(m.searchMatches, agent.ID)
+		if agent.ID == current {
+			m.searchIndex = i
+		}
+	}
+}
+
+// jumpToSearchMatch selects the current search match and scrolls to it
+func (m *model) jumpToSearchMatch() {
+	if m.searchIndex >= len(m.searchMatches) {
+		return
+	}
+	agentID := m.searchMatches[m.searchIndex]
+	m.selectedAgentID = agentID
+	m.contentDirty = true
+	if !m.ready {
+		return
+	}
+	m.updateViewportContent()
+	
+	// Scroll so the agent's first line is near the top of the viewport
+	firstLine := -1
+	for line, id := range m.agentLineMap {
+		if id == agentID && (firstLine == -1 || line < firstLine) {
+			firstLine = line
+		}
+	}
+	if firstLine >= 0 {
+		m.viewport.SetYOffset(max(firstLine-2, 0))
+	}
+}
+
+// renderTooSmall renders the notice shown when the terminal is below the minimum size
+func (m model) renderTooSmall() string {
+	textStyle := lipgloss.NewStyle().
+		Foreground(m.theme.TitleColor).
+		Bold(true)
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(m.theme.TacticalMuted)
+	
+	message := lipgloss.JoinVertical(lipgloss.Center,
+		textStyle.Render("Terminal too small"),
+		mutedStyle.Render(fmt.Sprintf("need at least %dx%d, have %dx%d",
+			m.prefs.MinWidth, m.prefs.MinHeight, m.termWidth, m.termHeight)),
+	)
+	
+	return lipgloss.Place(m.termWidth, m.termHeight, lipgloss.Center, lipgloss.Center, message)
+}
+
+// actionHint suggests an operator next step for agents matching a state
+type actionHint struct {
+	matches func(agent Agent) bool
+	hint    string
+}
+
+// actionHintRules drive the details panel's suggested next steps, in
+// display order; every matching rule contributes its hint
+var actionHintRules = []actionHint{
+	{func(a Agent) bool { return a.IsDead }, "Check why it dropped before redeploying"},
+	{func(a Agent) bool { return !a.IsDead && a.Burned }, "Migrate to a fresh implant or abandon the host"},
+	{func(a Agent) bool { return !a.IsDead && !a.Burned && a.IsSession && a.IsPrivileged }, "Dump creds (hashdump / lsass)"},
+	{func(a Agent) bool { return !a.IsDead && !a.Burned && a.IsSession && !a.IsPrivileged }, "Escalate (getsystem / local privesc enum)"},
+	{func(a Agent) bool { return !a.IsDead && !a.Burned && !a.IsSession && a.TasksCount == 0 }, "Queue recon (ps, netstat, ifconfig)"},
+	{func(a Agent) bool { return !a.IsDead && !a.IsSession && a.TasksCompleted < a.TasksCount }, "Tasks pending - wait for the next check-in"},
+	{func(a Agent) bool { return !a.IsDead && !a.Burned && !a.IsSession && a.IsPrivileged }, "Open an interactive session for privileged work"},
+}
+
+// agentActionHints returns the suggested next steps for agent
+func agentActionHints(agent Agent) []string {
+	var hints []string
+	for _, rule := range actionHintRules {
+		if rule.matches(agent) {
+			hints = append(hints, rule.hint)
+		}
+	}
+	return hints
+}
+
+// renderAgentDetailsPanel renders detailed information about the selected agent
+func (m model) renderAgentDetailsPanel() string {
+	// Only show if an agent is selected
+	if m.selectedAgentID == "" {
+		return ""
+	}
+	
+	// Find the selected agent
+	var selectedAgent *Agent
+	for i := range m.agents {
+		if m.agents[i].ID == m.selectedAgentID {
+			selectedAgent = &m.agents[i]
+			break
+		}
+	}
+	
+	if selectedAgent == nil {
+		return "" // Agent not found
+	}
+	
+	panelStyle := lipgloss.NewStyle().
+		Border(lipgloss.ThickBorder()).
+		BorderForeground(m.theme.TitleColor).
+		Background(lipgloss.Color("#1a1a2e")).
 		Padding(1, 2).
 		Width(50)
 	
@@ -1464,7 +3926,11 @@ func (m model) renderAgentDetailsPanel() string {
 	
 	// Basic Info
 	lines = append(lines, labelStyle.Render("🖥️  Hostname:"))
-	lines = append(lines, "   "+valueStyle.Render(selectedAgent.Hostname))
+	lines = append(lines, "   "+valueStyle.Render(selectedAgent.Hostname)+m.watchBadge(*selectedAgent)+m.ackBadge(*selectedAgent))
+	if selectedAgent.Incomplete {
+		lines = append(lines, "   "+lipgloss.NewStyle().Foreground(m.theme.TacticalMuted).Italic(true).
+			Render("◌ Incomplete data (OS/transport/address not reported yet)"))
+	}
 	lines = append(lines, "")
 	
 	lines = append(lines, labelStyle.Render("👤 User:"))
@@ -1481,15 +3947,37 @@ func (m model) renderAgentDetailsPanel() string {
 	
 	// Connection Info
 	lines = append(lines, labelStyle.Render("🌐 Connection:"))
-	lines = append(lines, "   "+valueStyle.Render("IP: "+selectedAgent.RemoteAddress))
+	lines = append(lines, "   "+valueStyle.Render("IP: "+displayAddress(selectedAgent.RemoteAddress)))
 	lines = append(lines, "   "+valueStyle.Render("Transport: "+selectedAgent.Transport))
+	if selectedAgent.ActiveC2 != "" {
+		lines = append(lines, "   "+valueStyle.Render("C2: "+selectedAgent.ActiveC2))
+	}
 	lines = append(lines, "")
 	
 	// System Info
 	lines = append(lines, labelStyle.Render("💻 System:"))
 	lines = append(lines, "   "+valueStyle.Render("OS: "+selectedAgent.OS))
 	lines = append(lines, "   "+valueStyle.Render("Arch: "+selectedAgent.Arch))
-	lines = append(lines, "   "+valueStyle.Render("PID: "+fmt.Sprintf("%d", selectedAgent.PID)))
+	pidLine := "   " + valueStyle.Render("PID: "+fmt.Sprintf("%d", selectedAgent.PID))
+	if m.duplicatePIDs[selectedAgent.ID] {
+		pidLine += " " + lipgloss.NewStyle().
+			Foreground(m.theme.WarningColor).
+			Bold(true).
+			Render("⚠ DUPLICATE")
+	}
+	lines = append(lines, pidLine)
+	version := selectedAgent.Version
+	if version == "" {
+		version = unknownVersion
+	}
+	versionLine := "   " + valueStyle.Render("Version: "+version)
+	if m.skewedVersions[selectedAgent.ID] {
+		versionLine += " " + lipgloss.NewStyle().
+			Foreground(m.theme.WarningColor).
+			Bold(true).
+			Render(fmt.Sprintf("⇩ OLD BUILD (fleet: %s)", m.modalVersion))
+	}
+	lines = append(lines, versionLine)
 	// Process name (if available) - press 'p' to toggle full path
 	if selectedAgent.Filename != "" {
 		// Check if this agent's process path is expanded
@@ -1528,16 +4016,41 @@ func (m model) renderAgentDetailsPanel() string {
 	}
 	lines = append(lines, "   "+lipgloss.NewStyle().Foreground(statusColor).Bold(true).Render(typeLabel))
 	
+	// Time in current state (alive/dead, session/beacon, privilege)
+	dwellStyle := lipgloss.NewStyle().Foreground(m.theme.TacticalMuted)
+	if dwell := stateDwell(selectedAgent.StatusSince); dwell != "" {
+		status := "Alive"
+		if selectedAgent.IsDead {
+			status = "Dead"
+		}
+		lines = append(lines, "   "+dwellStyle.Render(fmt.Sprintf("⏱ %s for %s", status, dwell)))
+	}
+	if dwell := stateDwell(selectedAgent.TypeSince); dwell != "" && !selectedAgent.IsDead {
+		agentType := "Beacon"
+		if selectedAgent.IsSession {
+			agentType = "Session"
+		}
+		lines = append(lines, "   "+dwellStyle.Render(fmt.Sprintf("⏱ %s for %s", agentType, dwell)))
+	}
+	if dwell := stateDwell(selectedAgent.PrivilegeSince); dwell != "" && selectedAgent.IsPrivileged {
+		lines = append(lines, "   "+dwellStyle.Render(fmt.Sprintf("⏱ Admin for %s", dwell)))
+	}
+	
 	// Check-in time
 	if !selectedAgent.IsDead && selectedAgent.LastCheckin > 0 {
 		checkinTime := time.Unix(selectedAgent.LastCheckin, 0)
-		lines = append(lines, "   "+valueStyle.Render("Last Check-in: "+checkinTime.Format("15:04:05")))
+		lines = append(lines, "   "+valueStyle.Render("Last Check-in: "+checkinTime.Format("15:04:05")+" ("+formatRelativeTime(checkinTime)+")"))
 	}
 	
 	// Beacon info
 	if !selectedAgent.IsSession && selectedAgent.Interval > 0 {
 		intervalSec := selectedAgent.Interval / 1000000000 // Convert nanoseconds to seconds
 		lines = append(lines, "   "+valueStyle.Render(fmt.Sprintf("Interval: %ds", intervalSec)))
+		if m.isSlowTransport(*selectedAgent) {
+			tolerance := m.clientOpts.ToleranceFor(selectedAgent.Transport)
+			lines = append(lines, "   "+lipgloss.NewStyle().Foreground(m.theme.TacticalMuted).Italic(true).
+				Render(fmt.Sprintf("🐢 Slow transport: dead after %.0f× interval", tolerance)))
+		}
 		if selectedAgent.NextCheckin > 0 {
 			nextTime := time.Unix(selectedAgent.NextCheckin, 0)
 			lines = append(lines, "   "+valueStyle.Render("Next Check-in: "+nextTime.Format("15:04:05")))
@@ -1575,6 +4088,16 @@ func (m model) renderAgentDetailsPanel() string {
 		lines = append(lines, "   "+valueStyle.Render(fmt.Sprintf("Completed: %d", selectedAgent.TasksCompleted)))
 	}
 	
+	// Suggested next steps (in the Sliver client; this monitor is read-only)
+	if hints := agentActionHints(*selectedAgent); len(hints) > 0 {
+		hintStyle := lipgloss.NewStyle().Foreground(m.theme.TacticalMuted)
+		lines = append(lines, "")
+		lines = append(lines, labelStyle.Render("🧭 Suggested Next Steps:"))
+		for _, hint := range hints {
+			lines = append(lines, "   "+hintStyle.Render("→ "+hint))
+		}
+	}
+	
 	lines = append(lines, "")
 	lines = append(lines, lipgloss.NewStyle().
 		Foreground(m.theme.TacticalMuted).
@@ -1607,15 +4130,41 @@ func (m *model) buildHelpContent() string {
 	helpLines = append(helpLines, textStyle.Render("  ?             Toggle this help menu"))
 	helpLines = append(helpLines, textStyle.Render("  q, Ctrl+C     Quit application"))
 	helpLines = append(helpLines, textStyle.Render("  r             Refresh agents from Sliver server"))
-	helpLines = append(helpLines, textStyle.Render("  ESC           Deselect agent / Clear number buffer"))
+	helpLines = append(helpLines, textStyle.Render("  R             Retry failed domain lookups (resolved ones are kept)"))
+	helpLines = append(helpLines, textStyle.Render("  S             Switch server (next operator config, y to confirm)"))
+	helpLines = append(helpLines, textStyle.Render("  /             Search agents (host, user, ID, IP, OS, transport)"))
+	helpLines = append(helpLines, textStyle.Render("  L             Operations log (task timeline, e to export)"))
+	helpLines = append(helpLines, textStyle.Render("  w             Alert times: absolute (15:04) ↔ relative (2m ago)"))
+	helpLines = append(helpLines, textStyle.Render("  m             Network Map symbol legend on/off"))
+	helpLines = append(helpLines, textStyle.Render("  O             Task queue order (most pending → nearest done → next check-in)"))
+	helpLines = append(helpLines, textStyle.Render("  s             Snapshot the screen to .ansi.txt (cat to replay) + plain .txt"))
+	helpLines = append(helpLines, textStyle.Render("  n / N         Next / previous search match"))
+	helpLines = append(helpLines, textStyle.Render("  I             Raw field inspector for the selected agent (DEBUG_PANEL=1 only)"))
+	helpLines = append(helpLines, textStyle.Render("  ESC           Deselect agent / Clear number buffer / Dismiss banner / Clear search"))
 	helpLines = append(helpLines, "")
 	
 	// VIEW CONTROLS
 	helpLines = append(helpLines, sectionStyle.Render("VIEW CONTROLS"))
 	helpLines = append(helpLines, textStyle.Render("  v             Cycle through views (Box → Table → Dashboard → Network Map)"))
 	helpLines = append(helpLines, textStyle.Render("  d             Jump directly to Dashboard view"))
+	helpLines = append(helpLines, textStyle.Render("  Alt+1..4      Jump to Box / Table / Dashboard / Network Map"))
 	helpLines = append(helpLines, textStyle.Render("  t             Cycle through color themes"))
+	helpLines = append(helpLines, textStyle.Render("  T             Swap back to the previous theme"))
+	helpLines = append(helpLines, textStyle.Render("  Alt+T         Theme picker (recently used themes first)"))
 	helpLines = append(helpLines, textStyle.Render("  i             Toggle icon style (Nerd Font ↔ Emoji)"))
+	helpLines = append(helpLines, textStyle.Render("  D             Dead agent placement (mixed → bottom → top)"))
+	helpLines = append(helpLines, textStyle.Render("  l             Flat agent list ↔ pivot tree (Box/Tree views)"))
+	helpLines = append(helpLines, textStyle.Render("  P             Highlight privileged agents with a background tint"))
+	helpLines = append(helpLines, textStyle.Render("  B             Theme backgrounds on agents (session/beacon/dead/new)"))
+	helpLines = append(helpLines, textStyle.Render("  z             Quiet mode (hide help footer, debug text, minor alerts)"))
+	helpLines = append(helpLines, textStyle.Render("  V             Header debug text (Scroll % → + Term size → none)"))
+	helpLines = append(helpLines, textStyle.Render("  K             Transport color legend (MTLS/HTTP/DNS/TCP)"))
+	helpLines = append(helpLines, textStyle.Render("  W             Fleet status in the terminal title (e.g. 12S 30B (2 crit))"))
+	helpLines = append(helpLines, textStyle.Render("  u             Short usernames (hide DOMAIN\\ prefix in lists)"))
+	helpLines = append(helpLines, textStyle.Render("  F             Filter by privilege + type (e.g. privileged sessions only)"))
+	helpLines = append(helpLines, textStyle.Render("  c             Count hosts ↔ agents in footer, stats and map totals"))
+	helpLines = append(helpLines, textStyle.Render("                (an agent is one implant connection; a host is a unique"))
+	helpLines = append(helpLines, textStyle.Render("                hostname and may run several agents)"))
 	helpLines = append(helpLines, "")
 	
 	// DASHBOARD NAVIGATION
@@ -1627,6 +4176,7 @@ func (m *model) buildHelpContent() string {
 	helpLines = append(helpLines, textStyle.Render("  F3            Jump to OPERATIONS page"))
 	helpLines = append(helpLines, textStyle.Render("  F4            Jump to SECURITY page"))
 	helpLines = append(helpLines, textStyle.Render("  F5            Jump to ANALYTICS page"))
+	helpLines = append(helpLines, textStyle.Render("  Tab           Pages past F5: DOMAINS (agents by AD domain), SUBNET"))
 	helpLines = append(helpLines, "")
 	
 	// NETWORK TOPOLOGY (Dashboard and Network Map views)
@@ -1634,6 +4184,9 @@ func (m *model) buildHelpContent() string {
 	helpLines = append(helpLines, textStyle.Render("  e             Expand/collapse all subnets"))
 	helpLines = append(helpLines, textStyle.Render("  0-9           Enter subnet number (multi-digit supported)"))
 	helpLines = append(helpLines, textStyle.Render("  Enter         Toggle selected subnet expand/collapse"))
+	helpLines = append(helpLines, textStyle.Render("  o             Focus selected subnet (expand it, collapse the rest)"))
+	helpLines = append(helpLines, textStyle.Render("  >             Drill into selected subnet (SUBNET page)"))
+	helpLines = append(helpLines, textStyle.Render("  y / Y         Export IPs (all, or typed subnet) / privileged IPs"))
 	helpLines = append(helpLines, "")
 	
 	// SCROLLING (Help Menu)
@@ -1666,6 +4219,9 @@ func (m *model) buildHelpContent() string {
 	// AGENT DETAILS PANEL
 	helpLines = append(helpLines, sectionStyle.Render("AGENT DETAILS PANEL (When Agent Selected)"))
 	helpLines = append(helpLines, textStyle.Render("  p             Toggle process path (filename ↔ full path)"))
+	helpLines = append(helpLines, textStyle.Render("  a             Acknowledge agent (silence its alerts for 15m, shows ack'd)"))
+	helpLines = append(helpLines, textStyle.Render("  x             Hide agent from views and counts (local only, this session)"))
+	helpLines = append(helpLines, textStyle.Render("  X             Unhide all hidden agents"))
 	helpLines = append(helpLines, textStyle.Render("  ESC           Close agent details panel"))
 	helpLines = append(helpLines, "")
 	
@@ -1676,6 +4232,8 @@ func (m *model) buildHelpContent() string {
 	helpLines = append(helpLines, textStyle.Render("  🔴 Red        Dead agent (missed check-ins)"))
 	helpLines = append(helpLines, textStyle.Render("  💎 Diamond    Privileged access (SYSTEM/root)"))
 	helpLines = append(helpLines, textStyle.Render("  ✨ Sparkle    Recently connected (new agent)"))
+	helpLines = append(helpLines, textStyle.Render("  ⚠  Warning    Duplicate PID on host (re-registered implant)"))
+	helpLines = append(helpLines, textStyle.Render("  ⚑  Flag       Watched host (watch_list in prefs)"))
 	helpLines = append(helpLines, "")
 	
 	// ALERT PANEL
@@ -1705,6 +4263,125 @@ func (m *model) buildHelpContent() string {
 
 // renderHelpMenu renders a comprehensive help overlay
 func (m *model) renderHelpMenu() string {
+	return m.renderScrollOverlay("?/ESC: close")
+}
+
+// renderOpsLog renders the operations log overlay (content is set when it opens)
+func (m *model) renderOpsLog() string {
+	return m.renderScrollOverlay("e: export • L/ESC: close")
+}
+
+// inspectorEnabled reports whether the raw field inspector is switched on
+// (DEBUG_PANEL=1, like the tactical panel dump); it stays out of the normal
+// UI otherwise
+func inspectorEnabled() bool {
+	return os.Getenv("DEBUG_PANEL") == "1"
+}
+
+// buildInspectorContent dumps every models.Agent field of the inspected
+// agent as received (after conversion), plus readable forms of the raw
+// interval/timestamp integers
+func (m model) buildInspectorContent() string {
+	titleStyle := lipgloss.NewStyle().Foreground(m.theme.TitleColor).Bold(true)
+	keyStyle := lipgloss.NewStyle().Foreground(m.theme.TacticalSection)
+	valueStyle := lipgloss.NewStyle().Foreground(m.theme.TacticalValue)
+	mutedStyle := lipgloss.NewStyle().Foreground(m.theme.TacticalMuted)
+	
+	var lines []string
+	lines = append(lines, titleStyle.Render("🔬 RAW AGENT FIELDS"))
+	lines = append(lines, "")
+	
+	var agent *Agent
+	for i := range m.allAgents {
+		if m.allAgents[i].ID == m.inspectAgentID {
+			agent = &m.allAgents[i]
+			break
+		}
+	}
+	if agent == nil {
+		lines = append(lines, mutedStyle.Render("  Agent "+m.inspectAgentID+" is no longer reported by the server"))
+		return strings.Join(lines, "\n")
+	}
+	
+	value := reflect.ValueOf(*agent)
+	for i := 0; i < value.NumField(); i++ {
+		var formatted string
+		switch field := value.Field(i).Interface().(type) {
+		case time.Time:
+			formatted = "(zero)"
+			if !field.IsZero() {
+				formatted = field.Format(time.RFC3339)
+			}
+		case []Agent:
+			formatted = fmt.Sprintf("%d children", len(field))
+		default:
+			formatted = fmt.Sprintf("%#v", field)
+		}
+		lines = append(lines, fmt.Sprintf("  %s %s",
+			keyStyle.Render(padText(value.Type().Field(i).Name, 16)),
+			valueStyle.Render(formatted)))
+	}
+	
+	// How the raw integers read (Interval/Jitter are nanoseconds, check-ins unix seconds)
+	lines = append(lines, "")
+	lines = append(lines, titleStyle.Render("INTERPRETED"))
+	lines = append(lines, fmt.Sprintf("  %s %s", keyStyle.Render(padText("Interval", 16)), valueStyle.Render(time.Duration(agent.Interval).String())))
+	lines = append(lines, fmt.Sprintf("  %s %s", keyStyle.Render(padText("Jitter", 16)), valueStyle.Render(time.Duration(agent.Jitter).String())))
+	for _, checkin := range []struct {
+		name string
+		unix int64
+	}{{"LastCheckin", agent.LastCheckin}, {"NextCheckin", agent.NextCheckin}} {
+		formatted := "(not reported)"
+		if checkin.unix > 0 {
+			at := time.Unix(checkin.unix, 0)
+			formatted = fmt.Sprintf("%s (%s)", at.Format("2006-01-02 15:04:05"), formatRelativeTime(at))
+		}
+		lines = append(lines, fmt.Sprintf("  %s %s", keyStyle.Render(padText(checkin.name, 16)), valueStyle.Render(formatted)))
+	}
+	
+	return strings.Join(lines, "\n")
+}
+
+// buildOpsLogContent renders the task lifecycle timeline, oldest first
+func (m model) buildOpsLogContent() string {
+	titleStyle := lipgloss.NewStyle().Foreground(m.theme.TitleColor).Bold(true)
+	timeStyle := lipgloss.NewStyle().Foreground(m.theme.TacticalMuted)
+	hostStyle := lipgloss.NewStyle().Foreground(m.theme.HostnameColor).Bold(true)
+	valueStyle := lipgloss.NewStyle().Foreground(m.theme.TacticalValue)
+	
+	var lines []string
+	lines = append(lines, titleStyle.Render("📜 OPERATIONS LOG"))
+	lines = append(lines, "")
+	
+	events := m.opsLog.GetEvents()
+	if len(events) == 0 {
+		lines = append(lines, timeStyle.Render("  No task activity recorded yet"))
+		return strings.Join(lines, "\n")
+	}
+	
+	for _, event := range events {
+		kindColor := m.theme.BeaconColor
+		if event.Kind == tracking.OpsTaskCompleted {
+			kindColor = m.theme.SessionColor
+		}
+		shortID := event.AgentID
+		if len(shortID) > 8 {
+			shortID = shortID[:8]
+		}
+		lines = append(lines, fmt.Sprintf("  %s  %s  %s %s  %s",
+			timeStyle.Render(event.Timestamp.Format("15:04:05")),
+			lipgloss.NewStyle().Foreground(kindColor).Bold(true).Render(padText(event.Kind.String(), 13)),
+			hostStyle.Render(event.AgentName),
+			timeStyle.Render("("+shortID+")"),
+			valueStyle.Render(fmt.Sprintf("%d/%d done", event.TasksCompleted, event.TasksCount))))
+	}
+	
+	return strings.Join(lines, "\n")
+}
+
+// renderScrollOverlay renders helpViewport as a bordered full-screen overlay
+// with a scroll indicator ending in closeHint
+func (m *model) renderScrollOverlay(closeHint string) string {
 	titleColor := m.theme.TitleColor
 	descColor := m.theme.TacticalValue
 	
@@ -1741,7 +4418,7 @@ func (m *model) renderHelpMenu() string {
 		scrollPercent := int(m.helpViewport.ScrollPercent() * 100)
 		scrollInfo = lipgloss.NewStyle().
 			Foreground(descColor).
-			Render(fmt.Sprintf("\n  📜 Scroll: %d%% • ↑↓/jk: line • PgUp/PgDn: page • Home/End: jump • ?/ESC: close", scrollPercent))
+			Render(fmt.Sprintf("\n  📜 Scroll: %d%% • ↑↓/jk: line • PgUp/PgDn: page • Home/End: jump • %s", scrollPercent, closeHint))
 	}
 	
 	return bordered + scrollInfo
@@ -1788,95 +4465,34 @@ func (m model) renderTacticalPanel() string {
 	lines = append(lines, "")
 
 	// Analyze data
-	subnetHosts := make(map[string]map[string]bool) // subnet -> unique hostnames
+	subnetHosts := make(map[string]map[string]bool) // subnet -> countKeys (agents or hostnames)
 	domains := make(map[string]int)
-	osHosts := make(map[string]map[string]bool) // OS type -> unique hostnames
+	osHosts := make(map[string]map[string]bool) // OS type -> countKeys (agents or hostnames)
 	transports := make(map[string]int)
 	privilegedCount := 0
 	pivotCount := 0
 	newCount := 0
 
 	for _, agent := range m.agents {
-		// Extract subnet (first 3 octets) and track unique hostnames
-		if agent.RemoteAddress != "" {
-			parts := strings.Split(agent.RemoteAddress, ".")
-			if len(parts) >= 3 {
-				subnet := strings.Join(parts[:3], ".") + ".0/24"
-				if subnetHosts[subnet] == nil {
-					subnetHosts[subnet] = make(map[string]bool)
-				}
-				subnetHosts[subnet][agent.Hostname] = true // Track unique hostnames
-			}
+		// Extract subnet (first 3 octets, or the Unknown bucket) and track unique hostnames
+		subnet := extractSubnet(agent.RemoteAddress)
+		if subnetHosts[subnet] == nil {
+			subnetHosts[subnet] = make(map[string]bool)
 		}
+		subnetHosts[subnet][m.countKey(agent)] = true
 
 		// Extract domain using multiple methods (priority order)
-		var domain string
-		
-		// Method 1 (HIGHEST PRIORITY): Use domain from background query cache
-		// This is populated asynchronously by querying USERDNSDOMAIN from sessions
-		if cachedDomain, exists := m.domainCache[agent.ID]; exists {
-			domain = cachedDomain
-		}
-		
-		// Method 2: Use domain field if already populated (legacy/fallback)
-		if domain == "" && agent.Domain != "" {
-			domain = agent.Domain
-		}
-		
-		// Method 3: Extract from hostname if it contains FQDN (e.g., "m3sqlw.m3c.local")
-		if domain == "" {
-			domain = config.ExtractDomainFromHostname(agent.Hostname)
-		}
-		
-		// Method 4: Perform reverse DNS lookup on IP address to get FQDN (with caching)
-		if domain == "" && agent.RemoteAddress != "" {
-			// Check cache first
-			if cachedDomain, exists := m.dnsCache[agent.RemoteAddress]; exists {
-				domain = cachedDomain
-			} else {
-				// Perform DNS lookup (this may be slow, so we cache it)
-				resolvedDomain := config.ResolveDomainFromIP(agent.RemoteAddress)
-				m.dnsCache[agent.RemoteAddress] = resolvedDomain // Cache result (even if empty)
-				domain = resolvedDomain
-			}
-		}
-		
-		// Method 5: Fallback to NetBIOS domain from username (Windows: DOMAIN\username)
-		// Only use this as last resort if all DNS methods failed
-		if domain == "" && strings.Contains(agent.Username, "\\") {
-			netbiosDomain := strings.Split(agent.Username, "\\")[0]
-			
-			// Filter out system accounts that aren't real domains
-			domainUpper := strings.ToUpper(netbiosDomain)
-			if domainUpper != "NT AUTHORITY" && 
-			   domainUpper != "BUILTIN" && 
-			   domainUpper != "WORKGROUP" &&
-			   netbiosDomain != "" {
-				// Use NetBIOS name as fallback (not ideal but better than nothing)
-				domain = netbiosDomain
-			}
-		}
-		
-		// Normalize domain to lowercase for consistent counting
-		if domain != "" {
-			domain = strings.ToLower(domain)
-			domains[domain]++
+		if domain := m.resolveAgentDomain(agent); domain != "" {
+			domains[domain]++
 		}
 
 		// Count OS by unique hostnames
 		if agent.OS != "" {
-			osType := "Unknown"
-			if strings.Contains(strings.ToLower(agent.OS), "windows") {
-				osType = "Windows"
-			} else if strings.Contains(strings.ToLower(agent.OS), "linux") {
-				osType = "Linux"
-			} else if strings.Contains(strings.ToLower(agent.OS), "darwin") {
-				osType = "macOS"
-			}
+			osType := osFamily(agent.OS)
 			if osHosts[osType] == nil {
 				osHosts[osType] = make(map[string]bool)
 			}
-			osHosts[osType][agent.Hostname] = true // Track unique hostnames per OS
+			osHosts[osType][m.countKey(agent)] = true
 		}
 
 		// Count transports
@@ -1900,7 +4516,7 @@ func (m model) renderTacticalPanel() string {
 			hostCount := len(hosts)
 			lines = append(lines, fmt.Sprintf("  %s %s",
 				valueStyle.Render(subnet),
-				mutedStyle.Render(fmt.Sprintf("(%d hosts)", hostCount))))
+				mutedStyle.Render(fmt.Sprintf("(%d %s)", hostCount, m.countUnit(hostCount)))))
 		}
 	} else {
 		lines = append(lines, mutedStyle.Render("  No subnet data"))
@@ -1943,9 +4559,12 @@ func (m model) renderTacticalPanel() string {
 				valueStyle.Render(domain),
 				mutedStyle.Render(fmt.Sprintf("(%d users)", count))))
 		}
-	} else {
+	} else if len(m.domainQueries) == 0 {
 		lines = append(lines, mutedStyle.Render("  No domain data"))
 	}
+	if pending := len(m.domainQueries); pending > 0 {
+		lines = append(lines, "  "+m.spinner.View()+mutedStyle.Render(fmt.Sprintf(" resolving %d…", pending)))
+	}
 
 	// OS Distribution
 	lines = append(lines, "")
@@ -1997,6 +4616,32 @@ func (m model) renderTacticalPanel() string {
 			valueStyle.Render(fmt.Sprintf("%d", pivotCount))))
 	}
 
+	// Shared egress: several hosts connecting from one IP (NAT/proxy)
+	if len(m.sharedEgress) > 0 {
+		egressIPs := make([]string, 0, len(m.sharedEgress))
+		for ip := range m.sharedEgress {
+			egressIPs = append(egressIPs, ip)
+		}
+		sort.Slice(egressIPs, func(i, j int) bool {
+			if m.sharedEgress[egressIPs[i]] != m.sharedEgress[egressIPs[j]] {
+				return m.sharedEgress[egressIPs[i]] > m.sharedEgress[egressIPs[j]]
+			}
+			return egressIPs[i] < egressIPs[j]
+		})
+		
+		lines = append(lines, "")
+		lines = append(lines, sectionStyle.Render(sharedEgressBadge+" Shared Egress"))
+		for i, ip := range egressIPs {
+			if i == 3 {
+				lines = append(lines, mutedStyle.Render(fmt.Sprintf("  +%d more", len(egressIPs)-3)))
+				break
+			}
+			lines = append(lines, fmt.Sprintf("  %s %s",
+				valueStyle.Render(ip),
+				mutedStyle.Render(fmt.Sprintf("(%d hosts)", m.sharedEgress[ip]))))
+		}
+	}
+
 	// Activity
 	if newCount > 0 {
 		lines = append(lines, "")
@@ -2022,6 +4667,146 @@ func (m model) renderTacticalPanel() string {
 	return rendered
 }
 
+// netbiosDomain returns the NetBIOS domain from a Windows "DOMAIN\user"
+// username, or "" if there is none or it is a built-in pseudo-domain
+func netbiosDomain(username string) string {
+	idx := strings.Index(username, "\\")
+	if idx <= 0 {
+		return ""
+	}
+	domain := username[:idx]
+	
+	// Filter out system accounts that aren't real domains
+	switch strings.ToUpper(domain) {
+	case "NT AUTHORITY", "BUILTIN", "WORKGROUP":
+		return ""
+	}
+	return domain
+}
+
+// normalizeUsername strips any "DOMAIN\" prefix and lowercases the account
+// name so the same account seen on several hosts compares equal
+func normalizeUsername(username string) string {
+	if idx := strings.LastIndex(username, "\\"); idx != -1 {
+		username = username[idx+1:]
+	}
+	return strings.ToLower(strings.TrimSpace(username))
+}
+
+// Lookup cache bounds: entries are re-resolved after the TTL, failed
+// lookups sooner, and the oldest are evicted past the size limit
+const (
+	dnsCacheSize       = 4096
+	dnsCacheTTL        = 30 * time.Minute
+	dnsCacheMissTTL    = 2 * time.Minute
+	domainCacheSize    = 2048
+	domainCacheTTL     = time.Hour
+	domainCacheMissTTL = 5 * time.Minute
+)
+
+// newDNSCache creates the reverse DNS cache (IP -> domain)
+func newDNSCache() *config.TTLCache {
+	return config.NewTTLCache(dnsCacheSize, dnsCacheTTL, dnsCacheMissTTL)
+}
+
+// newDomainCache creates the session domain cache (sessionID -> domain)
+func newDomainCache() *config.TTLCache {
+	return config.NewTTLCache(domainCacheSize, domainCacheTTL, domainCacheMissTTL)
+}
+
+// resolveAgentDomain works out an agent's domain (lowercase, "" if unknown)
+func (m model) resolveAgentDomain(agent Agent) string {
+	var domain string
+	
+	// Method 1 (HIGHEST PRIORITY): Use domain from background query cache
+	// This is populated asynchronously by querying USERDNSDOMAIN from sessions
+	if cachedDomain, exists := m.domainCache.Get(agent.ID); exists {
+		domain = cachedDomain
+	}
+	
+	// Method 2: Use domain field if already populated (legacy/fallback)
+	if domain == "" && agent.Domain != "" {
+		domain = agent.Domain
+	}
+	
+	// Method 3: Extract from hostname if it contains FQDN (e.g., "m3sqlw.m3c.local")
+	if domain == "" {
+		domain = config.ExtractDomainFromHostname(agent.Hostname)
+	}
+	
+	// Method 4: Reverse DNS of the IP address. Only the cache is read here
+	// (this runs while rendering); queueDNSLookups fills it in the background
+	if domain == "" && agent.RemoteAddress != "" {
+		if cachedDomain, exists := m.dnsCache.Get(agent.RemoteAddress); exists {
+			domain = cachedDomain
+		}
+	}
+	
+	// Method 5: Fallback to NetBIOS domain from username (Windows: DOMAIN\username)
+	// Only use this as last resort if all DNS methods failed
+	if domain == "" {
+		domain = netbiosDomain(agent.Username)
+	}
+	
+	// Normalize domain to lowercase for consistent counting
+	return strings.ToLower(domain)
+}
+
+// queueDNSLookups starts background reverse DNS lookups for agents whose
+// domain can't be found any other way and whose address isn't cached (or
+// has expired) or already being looked up
+func (m *model) queueDNSLookups(agents []Agent) []tea.Cmd {
+	if m.demoFleet != nil {
+		return nil
+	}
+	var cmds []tea.Cmd
+	for _, agent := range agents {
+		address := agent.RemoteAddress
+		if address == "" || m.dnsLookups[address] {
+			continue
+		}
+		if domain, exists := m.domainCache.Get(agent.ID); exists && domain != "" {
+			continue
+		}
+		if agent.Domain != "" || config.ExtractDomainFromHostname(agent.Hostname) != "" {
+			continue
+		}
+		if _, exists := m.dnsCache.Get(address); exists {
+			continue
+		}
+		m.dnsLookups[address] = true
+		cmds = append(cmds, dnsLookupCmd(address, m.clientOpts.ConfigPath))
+	}
+	return cmds
+}
+
+// displayUsername formats a username for agent lines and table cells.
+// With ShortUsernames the "DOMAIN\" prefix is stripped; the details panel
+// and privilege detection always use the full Username.
+func (m model) displayUsername(username string) string {
+	if m.prefs == nil || !m.prefs.ShortUsernames {
+		return username
+	}
+	if idx := strings.LastIndex(username, "\\"); idx != -1 && idx < len(username)-1 {
+		return username[idx+1:]
+	}
+	return username
+}
+
+// userIdentity returns a normalized "user@domain" key for an agent's account.
+// Domain accounts use their NetBIOS domain; local accounts are scoped to the host.
+func userIdentity(agent Agent) string {
+	user := normalizeUsername(agent.Username)
+	if user == "" {
+		return ""
+	}
+	domain := strings.ToLower(netbiosDomain(agent.Username))
+	if domain == "" || strings.EqualFold(domain, agent.Hostname) {
+		domain = strings.ToLower(agent.Hostname) // Local account
+	}
+	return user + "@" + domain
+}
+
 // SubnetGroup represents a group of agents in the same subnet
 type SubnetGroup struct {
 	Subnet      string
@@ -2042,6 +4827,35 @@ func (m model) getAnimatedHorizontalArrow() string {
 	return arrows[m.animationFrame%len(arrows)]
 }
 
+// groupAgentsBySubnet groups agents by their /24 (see extractSubnet),
+// returning the groups and the subnets in sorted order
+func groupAgentsBySubnet(agents []Agent) (map[string]*SubnetGroup, []string) {
+	subnetGroups := make(map[string]*SubnetGroup)
+	var subnets []string
+	
+	for _, agent := range agents {
+		subnet := extractSubnet(agent.RemoteAddress)
+		
+		if _, exists := subnetGroups[subnet]; !exists {
+			subnetGroups[subnet] = &SubnetGroup{
+				Subnet: subnet,
+				Agents: []Agent{},
+			}
+			subnets = append(subnets, subnet)
+		}
+		
+		subnetGroups[subnet].Agents = append(subnetGroups[subnet].Agents, agent)
+		
+		// Check if any agent in this subnet is pivoted
+		if agent.ProxyURL != "" {
+			subnetGroups[subnet].HasPivots = true
+		}
+	}
+	
+	sort.Strings(subnets)
+	return subnetGroups, subnets
+}
+
 // renderNetworkMapView renders the network topology map with subnet-based layout
 func (m model) renderNetworkMapView() string {
 	var content strings.Builder
@@ -2063,10 +4877,6 @@ func (m model) renderNetworkMapView() string {
 	mutedStyle := lipgloss.NewStyle().
 		Foreground(m.theme.TacticalMuted)
 	
-	// Group agents by subnet
-	subnetGroups := make(map[string]*SubnetGroup)
-	var subnets []string
-	
 	// Get C2 servers
 	c2Servers := make(map[string]int)
 	for _, agent := range m.agents {
@@ -2081,34 +4891,12 @@ func (m model) renderNetworkMapView() string {
 	}
 	
 	// Group agents by subnet
-	for _, agent := range m.agents {
-		subnet := extractSubnet(agent.RemoteAddress)
-		if subnet == "" {
-			subnet = "Unknown"
-		}
-		
-		if _, exists := subnetGroups[subnet]; !exists {
-			subnetGroups[subnet] = &SubnetGroup{
-				Subnet: subnet,
-				Agents: []Agent{},
-			}
-			subnets = append(subnets, subnet)
-		}
-		
-		subnetGroups[subnet].Agents = append(subnetGroups[subnet].Agents, agent)
-		
-		// Check if any agent in this subnet is pivoted
-		if agent.ProxyURL != "" {
-			subnetGroups[subnet].HasPivots = true
-		}
-	}
-	
-	sort.Strings(subnets)
+	subnetGroups, subnets := groupAgentsBySubnet(m.agents)
 	
-	// Initialize expandedSubnets map for all subnets (default to collapsed)
+	// Initialize expandedSubnets map for all subnets (remembered state, else the default)
 	for _, subnet := range subnets {
 		if _, exists := m.expandedSubnets[subnet]; !exists {
-			m.expandedSubnets[subnet] = false // Default to collapsed
+			m.expandedSubnets[subnet] = m.defaultSubnetExpanded(subnet)
 		}
 	}
 	
@@ -2208,14 +4996,53 @@ func (m model) renderNetworkMapView() string {
 		}
 	}
 	
+	// Symbol legend (toggle with 'm')
+	if m.prefs != nil && m.prefs.MapLegend {
+		content.WriteString("\n")
+		for _, line := range strings.Split(m.renderMapLegend(), "\n") {
+			content.WriteString(leftPadding + line + "\n")
+		}
+	}
+	
 	// Navigation help
 	content.WriteString("\n")
 	content.WriteString(leftPadding)
-	content.WriteString(mutedStyle.Render("  Navigation: V - Cycle Views | E - Expand Subnets | Q - Quit"))
+	content.WriteString(mutedStyle.Render("  Navigation: v - Cycle Views | e - Expand Subnets | m - Legend | q - Quit"))
 	
 	return content.String()
 }
 
+// renderMapLegend explains the Network Map's symbols in the theme's colors,
+// drawn with the same icons the map uses for the current icon style
+func (m model) renderMapLegend() string {
+	mutedStyle := lipgloss.NewStyle().Foreground(m.theme.TacticalMuted)
+	entry := func(glyph string, color lipgloss.Color, meaning string) string {
+		return lipgloss.NewStyle().Foreground(color).Bold(true).Render(glyph) + " " + mutedStyle.Render(meaning)
+	}
+	
+	agents := strings.Join([]string{
+		entry(m.getAgentTypeIcon(Agent{IsSession: true}), m.theme.SessionColor, "session"),
+		entry(m.getAgentTypeIcon(Agent{}), m.theme.BeaconColor, "beacon"),
+		entry(m.getAgentTypeIcon(Agent{IsDead: true}), m.theme.DeadColor, "dead"),
+		entry(glyphPrivileged, m.theme.PrivilegedUser, "privileged"),
+		entry(glyphWatched, m.theme.WatchedColor, "watched"),
+		entry(sharedEgressBadge, m.theme.ProtocolDefault, "shared egress IP"),
+	}, "   ")
+	subnets := strings.Join([]string{
+		entry(glyphProxied, m.theme.TacticalMuted, "subnet reached via pivot"),
+		entry(m.getAnimatedArrow(), m.theme.TacticalMuted, "C2 link"),
+		entry("▶/▼", m.theme.TitleColor, "collapsed/expanded"),
+		entry(glyphDeadCount, m.theme.DeadColor, "dead count"),
+	}, "   ")
+	
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.theme.TacticalBorder).
+		Padding(0, 1)
+	return box.Render(lipgloss.NewStyle().Foreground(m.theme.TitleColor).Bold(true).Render("LEGEND") +
+		"\n" + agents + "\n" + subnets)
+}
+
 // renderC2Box renders the C2 infrastructure box
 func (m model) renderC2Box(c2Servers map[string]int) string {
 	boxStyle := lipgloss.NewStyle().
@@ -2232,8 +5059,17 @@ func (m model) renderC2Box(c2Servers map[string]int) string {
 	var lines []string
 	lines = append(lines, titleStyle.Render("🔥 C2 INFRASTRUCTURE"))
 	
-	for server, count := range c2Servers {
-		lines = append(lines, fmt.Sprintf("%s (%d agents)", server, count))
+	// Stable order so the box doesn't reshuffle between refreshes
+	servers := make([]string, 0, len(c2Servers))
+	for server := range c2Servers {
+		servers = append(servers, server)
+	}
+	sort.Strings(servers)
+	
+	for _, server := range servers {
+		suffix := fmt.Sprintf(" (%d agents)", c2Servers[server])
+		urlWidth := 48 - ansi.StringWidth(suffix) // Box width minus padding
+		lines = append(lines, truncateURLMiddle(server, urlWidth)+suffix)
 	}
 	
 	return boxStyle.Render(strings.Join(lines, "\n"))
@@ -2257,7 +5093,7 @@ func (m model) renderSubnetBox(group *SubnetGroup) string {
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(m.theme.TacticalBorder).
 		Padding(1, 2).
-		Width(24).
+		Width(24 + max(m.hostnameWidth(10)-10, 0)). // Widen for longer hostnames
 		Height(boxHeight)
 	
 	titleStyle := lipgloss.NewStyle().
@@ -2277,24 +5113,11 @@ func (m model) renderSubnetBox(group *SubnetGroup) string {
 	lines = append(lines, titleStyle.Render(expandIndicator+" "+group.Subnet))
 	lines = append(lines, mutedStyle.Render(strings.Repeat("─", 20)))
 	
-	// Count agents by type
-	sessionCount := 0
-	beaconCount := 0
-	privilegedCount := 0
-	deadCount := 0
-	
-	for _, agent := range group.Agents {
-		if agent.IsDead {
-			deadCount++
-		} else if agent.IsSession {
-			sessionCount++
-		} else {
-			beaconCount++
-		}
-		if agent.IsPrivileged {
-			privilegedCount++
-		}
-	}
+	// Count agents (or unique hosts, per the count mode) by type
+	sessionCount := m.countOf(group.Agents, func(agent Agent) bool { return !agent.IsDead && agent.IsSession })
+	beaconCount := m.countOf(group.Agents, func(agent Agent) bool { return !agent.IsDead && !agent.IsSession })
+	privilegedCount := m.countOf(group.Agents, func(agent Agent) bool { return agent.IsPrivileged })
+	deadCount := m.countOf(group.Agents, func(agent Agent) bool { return agent.IsDead })
 	
 	// Show agents based on expansion state (deduplicate by hostname)
 	// Group agents by hostname to avoid showing duplicate hosts
@@ -2335,6 +5158,9 @@ func (m model) renderSubnetBox(group *SubnetGroup) string {
 		hasSession := false
 		hasDead := false
 		hasPrivileged := false
+		lostPrivileged := false
+		sharesEgress := false
+		watched := m.isWatched(agent)
 		
 		for _, a := range hostsAgents {
 			if a.IsDead {
@@ -2342,8 +5168,13 @@ func (m model) renderSubnetBox(group *SubnetGroup) string {
 			} else if a.IsSession {
 				hasSession = true
 			}
-			if a.IsPrivileged {
+			if a.IsPrivileged && !a.IsDead {
 				hasPrivileged = true
+			} else if a.IsPrivileged {
+				lostPrivileged = true
+			}
+			if m.sharedEgress[agentIP(a.RemoteAddress)] > 0 {
+				sharesEgress = true
 			}
 		}
 		
@@ -2365,16 +5196,30 @@ func (m model) renderSubnetBox(group *SubnetGroup) string {
 		osIcon := m.getOSIcon(agent.OS)
 		hostTypeIcon := m.getHostTypeIcon(agent)
 		
+		// A host whose only privileged agents are dead keeps its 💎 (the map
+		// shows where admin access was lost), dimmed with dead_privilege_badge
 		privilege := ""
 		if hasPrivileged {
-			privilege = " 💎"
+			privilege = " " + glyphPrivileged
+		} else if lostPrivileged {
+			privilege = m.privilegeBadge(Agent{IsPrivileged: true, IsDead: true})
+			if privilege == "" {
+				privilege = " " + glyphPrivileged
+			}
+		}
+		if sharesEgress {
+			privilege += " " + lipgloss.NewStyle().Foreground(m.theme.ProtocolDefault).Render(sharedEgressBadge)
 		}
 		
 		agentStyle := lipgloss.NewStyle().Foreground(color)
 		
-		displayHostname := hostname
-		if len(displayHostname) > 10 {
-			displayHostname = displayHostname[:10]
+		displayHostname := m.displayHostname(hostname, 10)
+		
+		if watched {
+			displayHostname = lipgloss.NewStyle().
+				Foreground(m.theme.WatchedColor).
+				Bold(true).
+				Render(glyphWatched + displayHostname)
 		}
 		
 		lines = append(lines, fmt.Sprintf("%s %s %s %s%s", 
@@ -2390,16 +5235,16 @@ func (m model) renderSubnetBox(group *SubnetGroup) string {
 	// Summary line
 	summaryParts := []string{}
 	if sessionCount > 0 {
-		summaryParts = append(summaryParts, fmt.Sprintf("%d◆", sessionCount))
+		summaryParts = append(summaryParts, fmt.Sprintf("%d%s", sessionCount, glyphSession))
 	}
 	if beaconCount > 0 {
-		summaryParts = append(summaryParts, fmt.Sprintf("%d◇", beaconCount))
+		summaryParts = append(summaryParts, fmt.Sprintf("%d%s", beaconCount, glyphBeacon))
 	}
 	if privilegedCount > 0 {
-		summaryParts = append(summaryParts, fmt.Sprintf("%d💎", privilegedCount))
+		summaryParts = append(summaryParts, fmt.Sprintf("%d%s", privilegedCount, glyphPrivileged))
 	}
 	if deadCount > 0 {
-		summaryParts = append(summaryParts, fmt.Sprintf("%d⚠️", deadCount))
+		summaryParts = append(summaryParts, fmt.Sprintf("%d%s", deadCount, glyphDeadCount))
 	}
 	
 	if len(summaryParts) > 0 {
@@ -2408,7 +5253,7 @@ func (m model) renderSubnetBox(group *SubnetGroup) string {
 	
 	// Pivot indicator
 	if group.HasPivots {
-		lines = append(lines, mutedStyle.Render("🔗 PROXIED"))
+		lines = append(lines, mutedStyle.Render(glyphProxied+" PROXIED"))
 	}
 	
 	return boxStyle.Render(strings.Join(lines, "\n"))
@@ -2448,19 +5293,64 @@ func (m model) joinBoxesHorizontally(boxes []string) string {
 	return strings.Join(result, "\n")
 }
 
+// dashboardPageDef is one dashboard page: its tab title and renderer
+type dashboardPageDef struct {
+	name   string
+	render func(model) string
+}
+
+// dashboardPages are the dashboard pages in tab order. Navigation and the
+// tab bar are derived from this slice, so pages can be reordered, removed or
+// added here alone; F1-F5 select the first five.
+var dashboardPages = []dashboardPageDef{
+	{"OVERVIEW", model.renderOverviewPage},
+	{"NETWORK INTEL", model.renderNetworkIntelPage},
+	{"OPERATIONS", model.renderOperationsPage},
+	{"SECURITY", model.renderSecurityPage},
+	{"ANALYTICS", model.renderAnalyticsPage},
+	{"DOMAINS", model.renderDomainsPage},
+	{subnetPageName, model.renderSubnetPage},
+}
+
+// subnetPageName is the title of the single-subnet drill-down page
+const subnetPageName = "SUBNET"
+
+// dashboardPageIndex returns the index of the named page (0 if missing)
+func dashboardPageIndex(name string) int {
+	for i, page := range dashboardPages {
+		if page.name == name {
+			return i
+		}
+	}
+	return 0
+}
+
+// viewLabel returns the current view name with a count of what it shows,
+// e.g. "Network Map (6 subnets)" or "Dashboard (OVERVIEW, 12 agents)"
+func (m model) viewLabel() string {
+	switch m.view.Type {
+	case config.ViewTypeDashboard:
+		pageName := ""
+		if m.dashboardPage >= 0 && m.dashboardPage < len(dashboardPages) {
+			pageName = dashboardPages[m.dashboardPage].name + ", "
+		}
+		return fmt.Sprintf("%s (%s%d agents)", m.view.Name, pageName, len(m.agents))
+	case config.ViewTypeNetworkMap:
+		return fmt.Sprintf("%s (%d subnets)", m.view.Name, len(m.subnetOrder))
+	case config.ViewTypeBox, config.ViewTypeTree:
+		if m.flatList {
+			return fmt.Sprintf("%s (%d agents, flat)", m.view.Name, len(m.agents))
+		}
+		return fmt.Sprintf("%s (%d agents)", m.view.Name, len(m.agents))
+	default:
+		return fmt.Sprintf("%s (%d agents)", m.view.Name, len(m.agents))
+	}
+}
+
 // renderDashboard renders the dashboard view with analytics panels
 func (m model) renderDashboard() string {
 	var content strings.Builder
 	
-	// Page names
-	pageNames := []string{
-		"OVERVIEW",
-		"NETWORK INTEL", 
-		"OPERATIONS",
-		"SECURITY",
-		"ANALYTICS",
-	}
-	
 	// Dashboard header with page indicator
 	headerStyle := lipgloss.NewStyle().
 		Foreground(m.theme.TitleColor).
@@ -2476,11 +5366,15 @@ func (m model) renderDashboard() string {
 	
 	// Build page tabs
 	var pageTabs []string
-	for i, name := range pageNames {
+	for i, page := range dashboardPages {
+		key := fmt.Sprintf("F%d:", i+1)
+		if i >= 5 {
+			key = "" // Beyond F5: Tab only
+		}
 		if i == m.dashboardPage {
-			pageTabs = append(pageTabs, currentPageStyle.Render(fmt.Sprintf("[F%d:%s]", i+1, name)))
+			pageTabs = append(pageTabs, currentPageStyle.Render(fmt.Sprintf("[%s%s]", key, page.name)))
 		} else {
-			pageTabs = append(pageTabs, pageStyle.Render(fmt.Sprintf(" F%d:%s ", i+1, name)))
+			pageTabs = append(pageTabs, pageStyle.Render(fmt.Sprintf(" %s%s ", key, page.name)))
 		}
 	}
 	
@@ -2488,21 +5382,11 @@ func (m model) renderDashboard() string {
 	content.WriteString("  ")
 	content.WriteString(strings.Join(pageTabs, " "))
 	content.WriteString("\n")
-	content.WriteString(pageStyle.Render("Navigate: Tab/Shift+Tab or F1-F5"))
+	content.WriteString(pageStyle.Render(fmt.Sprintf("Navigate: Tab/Shift+Tab or F1-F%d", min(len(dashboardPages), 5))))
 	content.WriteString("\n\n")
 	
-	// Render different pages based on dashboardPage
-	switch m.dashboardPage {
-	case 0: // Overview
-		content.WriteString(m.renderOverviewPage())
-	case 1: // Network Intel
-		content.WriteString(m.renderNetworkIntelPage())
-	case 2: // Operations
-		content.WriteString(m.renderOperationsPage())
-	case 3: // Security
-		content.WriteString(m.renderSecurityPage())
-	case 4: // Analytics
-		content.WriteString(m.renderAnalyticsPage())
+	if m.dashboardPage >= 0 && m.dashboardPage < len(dashboardPages) {
+		content.WriteString(dashboardPages[m.dashboardPage].render(m))
 	}
 	
 	return content.String()
@@ -2520,16 +5404,136 @@ func (m model) renderOverviewPage() string {
 	// Add a summary panel
 	summaryPanel := m.renderQuickStatsPanel()
 	
-	return topRow + "\n\n" + summaryPanel
+	// One-line OS mix across the width of the panels
+	ribbon := m.renderOSRibbon(lipgloss.Width(topRow))
+	
+	return ribbon + "\n\n" + topRow + "\n\n" + summaryPanel
+}
+
+// renderEmptyPanel draws a dashboard panel's no-data state: the panel title,
+// then an icon and message centered in the rest of panelStyle's box, so an
+// empty dashboard keeps its grid and reads as waiting rather than broken
+func (m model) renderEmptyPanel(panelStyle lipgloss.Style, title, message string) string {
+	titleStyle := lipgloss.NewStyle().
+		Foreground(m.theme.TacticalBorder).
+		Bold(true).
+		Underline(true)
+	
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(m.theme.TacticalMuted)
+	
+	innerWidth := panelStyle.GetWidth() - panelStyle.GetHorizontalPadding()
+	innerHeight := panelStyle.GetHeight() - panelStyle.GetVerticalPadding() - 2 // Title + gap
+	if innerWidth < 1 {
+		innerWidth = lipgloss.Width(message)
+	}
+	if innerHeight < 2 {
+		innerHeight = 2
+	}
+	
+	body := lipgloss.NewStyle().Width(innerWidth).Align(lipgloss.Center).Render(
+		mutedStyle.Render("◌") + "\n" + mutedStyle.Render(message))
+	body = lipgloss.PlaceVertical(innerHeight, lipgloss.Center, body)
+	
+	return panelStyle.Render(titleStyle.Render(title) + "\n\n" + body)
+}
+
+// osFamilies are the osFamily names in display order
+var osFamilies = []string{"Windows", "Linux", "macOS", "Unknown"}
+
+// osFamily normalizes an agent OS string to "Windows", "Linux", "macOS" or
+// "Unknown"
+func osFamily(os string) string {
+	osLower := strings.ToLower(os)
+	switch {
+	case strings.Contains(osLower, "windows"):
+		return "Windows"
+	case strings.Contains(osLower, "linux"):
+		return "Linux"
+	case strings.Contains(osLower, "darwin"):
+		return "macOS"
+	}
+	return "Unknown"
+}
+
+// osFamilyColor returns the theme color for an OS family's ribbon segment
+func (m model) osFamilyColor(family string) lipgloss.Color {
+	switch family {
+	case "Windows":
+		return m.theme.OSWindows
+	case "Linux":
+		return m.theme.OSLinux
+	case "macOS":
+		return m.theme.OSMacOS
+	}
+	return m.theme.TacticalMuted
+}
+
+// renderOSRibbon draws the live OS mix as one stacked bar with counts (as
+// agents or hosts, per the count mode), fitted to width cells
+func (m model) renderOSRibbon(width int) string {
+	labelStyle := lipgloss.NewStyle().Foreground(m.theme.TacticalSection).Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(m.theme.TacticalMuted)
+	
+	counts := make(map[string]int)
+	total := 0
+	for _, family := range osFamilies {
+		counts[family] = m.countOf(m.agents, func(agent Agent) bool {
+			return !agent.IsDead && osFamily(agent.OS) == family
+		})
+		total += counts[family]
+	}
+	if total == 0 {
+		return labelStyle.Render("OS ") + mutedStyle.Render("no live agents")
+	}
+	
+	var legend []string
+	for _, family := range osFamilies {
+		if counts[family] > 0 {
+			legend = append(legend, lipgloss.NewStyle().Foreground(m.osFamilyColor(family)).
+				Render(fmt.Sprintf("■ %s %d", family, counts[family])))
+		}
+	}
+	legendText := strings.Join(legend, "  ")
+	
+	barWidth := width - 3 - 2 - lipgloss.Width(legendText) // "OS " prefix, gap
+	if barWidth < 10 {
+		barWidth = 10
+	}
+	
+	// Proportional segments; every present family gets at least one cell
+	// and the largest absorbs rounding
+	cells := make(map[string]int)
+	used, largest := 0, ""
+	for _, family := range osFamilies {
+		if counts[family] == 0 {
+			continue
+		}
+		cells[family] = max(1, counts[family]*barWidth/total)
+		used += cells[family]
+		if largest == "" || counts[family] > counts[largest] {
+			largest = family
+		}
+	}
+	cells[largest] = max(1, cells[largest]+barWidth-used)
+	
+	var bar strings.Builder
+	for _, family := range osFamilies {
+		if cells[family] > 0 {
+			bar.WriteString(lipgloss.NewStyle().Foreground(m.osFamilyColor(family)).
+				Render(strings.Repeat("█", cells[family])))
+		}
+	}
+	return labelStyle.Render("OS ") + bar.String() + "  " + legendText
 }
 
 // renderNetworkIntelPage shows network topology and C2 infrastructure
 func (m model) renderNetworkIntelPage() string {
 	c2Panel := m.renderC2InfrastructurePanel()
 	networkPanel := m.renderNetworkTopologyPanel()
+	histogramPanel := m.renderSubnetHistogramPanel()
 	
-	// Could add more network-related panels here
-	topRow := lipgloss.JoinHorizontal(lipgloss.Top, c2Panel, "  ", networkPanel)
+	topRow := lipgloss.JoinHorizontal(lipgloss.Top, c2Panel, "  ", networkPanel, "  ", histogramPanel)
 	
 	return topRow
 }
@@ -2537,42 +5541,336 @@ func (m model) renderNetworkIntelPage() string {
 // renderOperationsPage shows task queues and beacon activity  
 func (m model) renderOperationsPage() string {
 	taskQueuePanel := m.renderTaskQueuePanel()
+	promotionPanel := m.renderPromotionPanel()
+	
+	trafficPanel := m.renderTrafficPanel()
 	
-	// For now, show task queue - can add more operational panels
-	return taskQueuePanel
+	topRow := lipgloss.JoinHorizontal(lipgloss.Top, taskQueuePanel, "  ", promotionPanel, "  ", trafficPanel)
+	
+	return topRow
 }
 
 // renderSecurityPage shows security status and privilege tracking
 func (m model) renderSecurityPage() string {
 	securityPanel := m.renderSecurityStatusPanel()
 	archPanel := m.renderArchitecturePanel()
+	processPanel := m.renderProcessPanel()
 	
-	topRow := lipgloss.JoinHorizontal(lipgloss.Top, securityPanel, "  ", archPanel)
+	topRow := lipgloss.JoinHorizontal(lipgloss.Top, securityPanel, "  ", archPanel, "  ", processPanel)
 	
-	return topRow
+	usersPanel := m.renderCompromisedUsersPanel()
+	
+	return topRow + "\n\n" + usersPanel
 }
 
 // renderAnalyticsPage shows historical data and trends
 func (m model) renderAnalyticsPage() string {
 	sparklinePanel := m.renderSparklinePanel()
+	transportPanel := m.renderTransportSparklinePanel()
+	cpuArchPanel := m.renderCPUArchPanel()
 	
-	// For now just sparklines - can add more analytics panels
-	return sparklinePanel
+	return lipgloss.JoinHorizontal(lipgloss.Top, sparklinePanel, "  ", transportPanel, "  ", cpuArchPanel)
 }
 
-// renderQuickStatsPanel shows a summary of key metrics
-func (m model) renderQuickStatsPanel() string {
+// unknownDomain names the group of agents whose domain couldn't be resolved
+const unknownDomain = "unknown domain"
+
+// domainGroup is one domain's agents on the DOMAINS page
+type domainGroup struct {
+	name       string
+	agents     []Agent
+	hosts      map[string]bool // Hostnames (lowercase)
+	privileged map[string]bool // userIdentity of privileged agents
+	dcs        map[string]bool // Hostnames that look like domain controllers
+	live       int
+}
+
+// looksLikeDC reports whether a hostname follows domain controller naming
+// (e.g. "DC01", "CORP-DC2")
+func looksLikeDC(hostname string) bool {
+	upper := strings.ToUpper(hostname)
+	return strings.HasPrefix(upper, "DC") || strings.Contains(upper, "-DC")
+}
+
+// groupAgentsByDomain groups agents by resolveAgentDomain, largest domain
+// (by hosts) first and the unknown domain last
+func (m model) groupAgentsByDomain() []*domainGroup {
+	byName := make(map[string]*domainGroup)
+	var groups []*domainGroup
+	for _, agent := range m.agents {
+		name := m.resolveAgentDomain(agent)
+		if name == "" {
+			name = unknownDomain
+		}
+		group := byName[name]
+		if group == nil {
+			group = &domainGroup{name: name, hosts: make(map[string]bool),
+				privileged: make(map[string]bool), dcs: make(map[string]bool)}
+			byName[name] = group
+			groups = append(groups, group)
+		}
+		group.agents = append(group.agents, agent)
+		group.hosts[strings.ToLower(agent.Hostname)] = true
+		if !agent.IsDead {
+			group.live++
+		}
+		if identity := userIdentity(agent); agent.IsPrivileged && identity != "" {
+			group.privileged[identity] = true
+		}
+		if looksLikeDC(agent.Hostname) {
+			group.dcs[agent.Hostname] = true
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if (groups[i].name == unknownDomain) != (groups[j].name == unknownDomain) {
+			return groups[j].name == unknownDomain
+		}
+		if len(groups[i].hosts) != len(groups[j].hosts) {
+			return len(groups[i].hosts) > len(groups[j].hosts)
+		}
+		return groups[i].name < groups[j].name
+	})
+	return groups
+}
+
+// renderDomainsPage groups agents by their resolved domain (the same
+// resolution as the tactical panel's domain count), one panel per domain
+// with its hosts, privileged accounts and domain controllers
+func (m model) renderDomainsPage() string {
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(m.theme.TacticalMuted).
+		Italic(true)
+	
+	groups := m.groupAgentsByDomain()
+	if len(groups) == 0 {
+		return mutedStyle.Render("  No agents yet")
+	}
+	
+	// Three panels per row, like the other dashboard pages
+	var rows []string
+	for start := 0; start < len(groups); start += 3 {
+		var panels []string
+		for _, group := range groups[start:min(start+3, len(groups))] {
+			if len(panels) > 0 {
+				panels = append(panels, "  ")
+			}
+			panels = append(panels, m.renderDomainPanel(group))
+		}
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, panels...))
+	}
+	return strings.Join(rows, "\n\n")
+}
+
+// renderDomainPanel draws one domain's panel for the DOMAINS page
+func (m model) renderDomainPanel(group *domainGroup) string {
 	panelStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(m.theme.TacticalBorder).
 		Padding(1, 2).
-		Width(120). // Wide panel for overview
-		Height(10)
+		Width(38)
 	
+	titleStyle := lipgloss.NewStyle().
+		Foreground(m.theme.TacticalBorder).
+		Bold(true).
+		Underline(true)
+	
+	labelStyle := lipgloss.NewStyle().
+		Foreground(m.theme.TacticalSection)
+	
+	valueStyle := lipgloss.NewStyle().
+		Foreground(m.theme.TacticalValue).
+		Bold(true)
+	
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(m.theme.TacticalMuted)
+	
+	privStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#ff5555")). // Red for privileged
+		Bold(true)
+	
+	name := strings.ToUpper(group.name)
+	if group.name == unknownDomain {
+		name = "UNKNOWN DOMAIN"
+	}
+	
+	var lines []string
+	lines = append(lines, titleStyle.Render("🏛 "+truncateText(name, 30)))
+	lines = append(lines, "")
+	lines = append(lines, fmt.Sprintf("%s %s   %s %s",
+		labelStyle.Render("Hosts:"), valueStyle.Render(fmt.Sprintf("%d", len(group.hosts))),
+		labelStyle.Render("Agents:"), valueStyle.Render(fmt.Sprintf("%d (%d live)", len(group.agents), group.live))))
+	
+	// Domain controllers
+	dcs := make([]string, 0, len(group.dcs))
+	for dc := range group.dcs {
+		dcs = append(dcs, dc)
+	}
+	sort.Strings(dcs)
+	dcText := mutedStyle.Render("none seen")
+	if len(dcs) > 0 {
+		dcText = privStyle.Render(truncateText(strings.Join(dcs, ", "), 28))
+	}
+	lines = append(lines, fmt.Sprintf("%s %s", labelStyle.Render("DCs:"), dcText))
+	
+	// Privileged accounts (deduplicated across hosts)
+	accounts := make([]string, 0, len(group.privileged))
+	for identity := range group.privileged {
+		accounts = append(accounts, identity)
+	}
+	sort.Strings(accounts)
+	lines = append(lines, "")
+	lines = append(lines, fmt.Sprintf("%s %s", labelStyle.Render("Privileged accounts:"),
+		valueStyle.Render(fmt.Sprintf("%d", len(accounts)))))
+	const maxAccounts = 4
+	for i, account := range accounts {
+		if i == maxAccounts {
+			lines = append(lines, mutedStyle.Render(fmt.Sprintf("  +%d more", len(accounts)-maxAccounts)))
+			break
+		}
+		lines = append(lines, privStyle.Render("  💎 "+truncateText(account, 28)))
+	}
+	
+	return panelStyle.Render(strings.Join(lines, "\n"))
+}
+
+// renderSubnetPage drills into the subnet picked from the number buffer:
+// its hosts as a table plus the OS/privilege, C2 and task panels, each
+// scoped to that subnet's agents
+func (m model) renderSubnetPage() string {
 	titleStyle := lipgloss.NewStyle().
 		Foreground(m.theme.TitleColor).
 		Bold(true)
 	
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(m.theme.TacticalMuted).
+		Italic(true)
+	
+	if m.drillSubnet == "" {
+		return mutedStyle.Render("  Type a subnet number (as listed under NETWORK INTEL) and press > to drill into it")
+	}
+	
+	// The panels all read m.agents, so render them from a copy that only
+	// holds this subnet's agents
+	scoped := m
+	scoped.agents = nil
+	live := 0
+	for _, agent := range m.agents {
+		if extractSubnet(agent.RemoteAddress) != m.drillSubnet {
+			continue
+		}
+		scoped.agents = append(scoped.agents, agent)
+		if !agent.IsDead {
+			live++
+		}
+	}
+	
+	title := titleStyle.Render("🔍 SUBNET "+m.drillSubnet) + "  " +
+		mutedStyle.Render(fmt.Sprintf("%d agent(s), %d live", len(scoped.agents), live))
+	if len(scoped.agents) == 0 {
+		return title + "\n\n" + mutedStyle.Render("  No agents in this subnet anymore")
+	}
+	
+	panels := lipgloss.JoinHorizontal(lipgloss.Top,
+		scoped.renderArchitecturePanel(), "  ",
+		scoped.renderC2InfrastructurePanel(), "  ",
+		scoped.renderTaskQueuePanel())
+	
+	return title + "\n\n" + scoped.renderTableView() + "\n\n" + panels
+}
+
+// activityWindow is the span the activity tracker keeps, e.g. "12h"
+func (m model) activityWindow() string {
+	return formatAge(time.Duration(m.activityTracker.MaxSamples) * m.activityTracker.SampleInterval)
+}
+
+// activityEmptyMessage is the empty state of the activity sparkline panels.
+// Samples are taken on agent changes and every SampleInterval, so the first
+// one normally lands with the first refresh.
+func (m model) activityEmptyMessage() string {
+	return fmt.Sprintf("Collecting samples (every %s)...", formatAge(m.activityTracker.SampleInterval))
+}
+
+// renderTransportSparklinePanel shows how the live transport mix evolves
+// over the activity tracker's window (one sparkline per transport)
+func (m model) renderTransportSparklinePanel() string {
+	panelStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.theme.TacticalBorder).
+		Padding(1, 2).
+		Width(38).
+		Height(18)
+	
+	titleStyle := lipgloss.NewStyle().
+		Foreground(m.theme.TacticalBorder).
+		Bold(true).
+		Underline(true)
+	
+	labelStyle := lipgloss.NewStyle().
+		Foreground(m.theme.TacticalSection)
+	
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(m.theme.TacticalMuted)
+	
+	title := fmt.Sprintf("📡 TRANSPORT MIX (%s)", m.activityWindow())
+	samples := m.activityTracker.GetSamples()
+	if len(samples) == 0 {
+		return m.renderEmptyPanel(panelStyle, title, m.activityEmptyMessage())
+	}
+	
+	var lines []string
+	lines = append(lines, titleStyle.Render(title))
+	lines = append(lines, "")
+	
+	sparklineWidth := 18
+	current := samples[len(samples)-1]
+	for _, transport := range tracking.Transports {
+		sparkline := generateHistoricalSparkline(samples, "transport:"+transport, sparklineWidth, m.sparklineRamp())
+		lines = append(lines, fmt.Sprintf("%s %s  Now: %d",
+			labelStyle.Render(fmt.Sprintf("%-6s", strings.ToUpper(transport))),
+			lipgloss.NewStyle().Foreground(m.transportColor(transport)).Render(sparkline), // Same colors as the tree view's transport boxes
+			current.TransportCounts[transport]))
+	}
+	
+	lines = append(lines, "")
+	lines = append(lines, mutedStyle.Render(strings.Repeat(" ", 7)+generateTimeAxis(samples, sparklineWidth, m.activityTracker.StartTime))) // Align under the sparklines
+	
+	return panelStyle.Render(strings.Join(lines, "\n"))
+}
+
+// cpuArchOrder is the display order of normalizeArch buckets
+var cpuArchOrder = []string{"x64", "x86", "arm64", "arm", "unknown"}
+
+// normalizeArch buckets the many spellings of an implant's CPU architecture
+// (amd64, x86_64, 386, aarch64, ...) into x64, x86, arm64, arm or unknown
+func normalizeArch(arch string) string {
+	switch strings.ToLower(strings.TrimSpace(arch)) {
+	case "amd64", "x86_64", "x64":
+		return "x64"
+	case "386", "i386", "i686", "x86":
+		return "x86"
+	case "arm64", "aarch64":
+		return "arm64"
+	case "arm", "armv6", "armv7", "armv7l":
+		return "arm"
+	default:
+		return "unknown"
+	}
+}
+
+// renderCPUArchPanel shows live agents by CPU architecture (no OS breakdown)
+func (m model) renderCPUArchPanel() string {
+	panelStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.theme.TacticalBorder).
+		Padding(1, 2).
+		Width(38).
+		Height(18)
+	
+	titleStyle := lipgloss.NewStyle().
+		Foreground(m.theme.TacticalBorder).
+		Bold(true).
+		Underline(true)
+	
 	labelStyle := lipgloss.NewStyle().
 		Foreground(m.theme.TacticalSection)
 	
@@ -2580,38 +5878,91 @@ func (m model) renderQuickStatsPanel() string {
 		Foreground(m.theme.TacticalValue).
 		Bold(true)
 	
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(m.theme.TacticalMuted)
+	
+	barStyle := lipgloss.NewStyle().
+		Foreground(m.theme.StatsColor)
+	
 	var lines []string
-	lines = append(lines, titleStyle.Render("📈 QUICK STATS"))
+	lines = append(lines, titleStyle.Render("🧮 CPU ARCHITECTURE"))
 	lines = append(lines, "")
 	
-	// Count stats
+	counts := make(map[string]int)
 	totalAgents := 0
-	sessions := 0
-	beacons := 0
-	privileged := 0
-	dead := 0
-	
 	for _, agent := range m.agents {
-		totalAgents++
 		if agent.IsDead {
-			dead++
-			continue
-		}
-		if agent.IsSession {
-			sessions++
-		} else {
-			beacons++
+			continue // Skip dead agents
 		}
-		if agent.IsPrivileged {
-			privileged++
+		counts[normalizeArch(agent.Arch)]++
+		totalAgents++
+	}
+	
+	if totalAgents == 0 {
+		lines = append(lines, mutedStyle.Render("No active agents"))
+		return panelStyle.Render(strings.Join(lines, "\n"))
+	}
+	
+	for _, arch := range cpuArchOrder {
+		count := counts[arch]
+		if count == 0 {
+			continue
 		}
+		
+		percentage := float64(count) / float64(totalAgents) * 100
+		lines = append(lines, fmt.Sprintf("%s %s",
+			labelStyle.Render(fmt.Sprintf("%-8s", arch)),
+			valueStyle.Render(fmt.Sprintf("%d", count))))
+		
+		// Percentage bar
+		barLength := int(percentage / 10) // 10% per block
+		if barLength > 10 {
+			barLength = 10
+		}
+		bar := strings.Repeat("█", barLength) + strings.Repeat("░", 10-barLength)
+		lines = append(lines, fmt.Sprintf("  %s %s",
+			barStyle.Render(bar),
+			mutedStyle.Render(fmt.Sprintf("%.0f%%", percentage))))
+		lines = append(lines, "")
 	}
 	
-	activeAgents := totalAgents - dead
+	return panelStyle.Render(strings.Join(lines, "\n"))
+}
+
+// renderQuickStatsPanel shows a summary of key metrics
+func (m model) renderQuickStatsPanel() string {
+	panelStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.theme.TacticalBorder).
+		Padding(1, 2).
+		Width(120). // Wide panel for overview
+		Height(10)
+	
+	titleStyle := lipgloss.NewStyle().
+		Foreground(m.theme.TitleColor).
+		Bold(true)
+	
+	labelStyle := lipgloss.NewStyle().
+		Foreground(m.theme.TacticalSection)
+	
+	valueStyle := lipgloss.NewStyle().
+		Foreground(m.theme.TacticalValue).
+		Bold(true)
+	
+	var lines []string
+	lines = append(lines, titleStyle.Render("📈 QUICK STATS"))
+	lines = append(lines, "")
+	
+	// Count stats (agents or unique hosts, per the count mode)
+	activeAgents := m.countOf(m.agents, func(agent Agent) bool { return !agent.IsDead })
+	sessions := m.countOf(m.agents, func(agent Agent) bool { return !agent.IsDead && agent.IsSession })
+	beacons := m.countOf(m.agents, func(agent Agent) bool { return !agent.IsDead && !agent.IsSession })
+	privileged := m.countOf(m.agents, func(agent Agent) bool { return !agent.IsDead && agent.IsPrivileged })
+	dead := m.countOf(m.agents, func(agent Agent) bool { return agent.IsDead })
 	
 	// Build stats line
 	stats := fmt.Sprintf("%s %s  |  %s %s  |  %s %s  |  %s %s  |  %s %s",
-		labelStyle.Render("Total:"),
+		labelStyle.Render(fmt.Sprintf("Total %s:", m.countUnit(2))),
 		valueStyle.Render(fmt.Sprintf("%d", activeAgents)),
 		labelStyle.Render("Sessions:"),
 		valueStyle.Render(fmt.Sprintf("%d", sessions)),
@@ -2624,11 +5975,77 @@ func (m model) renderQuickStatsPanel() string {
 	
 	lines = append(lines, stats)
 	
+	// Progress toward the in-scope host baseline (if configured)
+	if summary, barLength := m.baselineProgress(); summary != "" {
+		bar := strings.Repeat("█", barLength) + strings.Repeat("░", 10-barLength)
+		lines = append(lines, "")
+		lines = append(lines, fmt.Sprintf("%s %s %s",
+			labelStyle.Render("Scope:"),
+			lipgloss.NewStyle().Foreground(m.theme.SessionColor).Render(bar),
+			valueStyle.Render(summary)))
+	}
+	
 	return panelStyle.Render(strings.Join(lines, "\n"))
 }
 
 
-// renderC2InfrastructurePanel shows active C2 servers with agent counts
+// c2Listener is one row of the C2 infrastructure table
+type c2Listener struct {
+	url       string
+	total     int
+	live      int
+	dead      int
+	protocols map[string]int
+	oldest    time.Time // Earliest FirstSeen on this listener
+	newest    time.Time // Latest FirstSeen on this listener
+}
+
+// groupC2Listeners groups agents by ActiveC2, largest listener first
+// (ties broken by URL so the order doesn't flicker between refreshes)
+func groupC2Listeners(agents []Agent) []c2Listener {
+	byURL := make(map[string]*c2Listener)
+	for _, agent := range agents {
+		url := agent.ActiveC2
+		if url == "" {
+			url = "Unknown"
+		}
+		listener, ok := byURL[url]
+		if !ok {
+			listener = &c2Listener{url: url, protocols: make(map[string]int)}
+			byURL[url] = listener
+		}
+		listener.total++
+		if agent.IsDead {
+			listener.dead++
+		} else {
+			listener.live++
+		}
+		listener.protocols[agent.Transport]++
+		if !agent.FirstSeen.IsZero() {
+			if listener.oldest.IsZero() || agent.FirstSeen.Before(listener.oldest) {
+				listener.oldest = agent.FirstSeen
+			}
+			if agent.FirstSeen.After(listener.newest) {
+				listener.newest = agent.FirstSeen
+			}
+		}
+	}
+	
+	listeners := make([]c2Listener, 0, len(byURL))
+	for _, listener := range byURL {
+		listeners = append(listeners, *listener)
+	}
+	sort.Slice(listeners, func(i, j int) bool {
+		if listeners[i].total != listeners[j].total {
+			return listeners[i].total > listeners[j].total
+		}
+		return listeners[i].url < listeners[j].url
+	})
+	return listeners
+}
+
+// renderC2InfrastructurePanel shows a per-listener table: agent count,
+// live/dead split, protocol mix and oldest/newest agent
 func (m model) renderC2InfrastructurePanel() string {
 	panelStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
@@ -2656,42 +6073,68 @@ func (m model) renderC2InfrastructurePanel() string {
 	lines = append(lines, titleStyle.Render("🌐 C2 INFRASTRUCTURE MAP"))
 	lines = append(lines, "")
 	
-	// Group agents by C2 server
-	c2Servers := make(map[string][]Agent)
-	for _, agent := range m.agents {
-		c2 := agent.ActiveC2
-		if c2 == "" {
-			c2 = "Unknown"
-		}
-		c2Servers[c2] = append(c2Servers[c2], agent)
+	listeners := groupC2Listeners(m.agents)
+	
+	if len(listeners) == 0 {
+		return m.renderEmptyPanel(panelStyle, "🌐 C2 INFRASTRUCTURE MAP", "No C2 listeners seen yet")
 	}
 	
-	if len(c2Servers) == 0 {
-		lines = append(lines, mutedStyle.Render("No C2 data available"))
-	} else {
-		for server, agents := range c2Servers {
-			lines = append(lines, labelStyle.Render(fmt.Sprintf("🌐 %s", server)))
-			
-			// Count protocols
-			protocols := make(map[string]int)
-			for _, agent := range agents {
-				protocols[agent.Transport]++
-			}
-			
-			// Show agent count
-			lines = append(lines, fmt.Sprintf("   %s %s",
-				valueStyle.Render(fmt.Sprintf("%d agents", len(agents))),
-				mutedStyle.Render("")))
-			
-			// Show protocol breakdown
-			var protoList []string
-			for proto, count := range protocols {
-				protoList = append(protoList, fmt.Sprintf("%s:%d", proto, count))
+	liveStyle := lipgloss.NewStyle().Foreground(m.theme.SessionColor)
+	deadStyle := lipgloss.NewStyle().Foreground(m.theme.DeadColor)
+	
+	// Table header: listener URL, then total / live / dead
+	const urlWidth = 18
+	lines = append(lines, mutedStyle.Render(fmt.Sprintf("%s %4s %4s %4s",
+		padText("LISTENER", urlWidth), "ALL", "LIVE", "DEAD")))
+	
+	// Three lines per listener; keep the table inside the panel height
+	const maxListeners = 4
+	shown := listeners
+	if len(listeners) > maxListeners {
+		shown = listeners[:maxListeners-1]
+	}
+	
+	for _, listener := range shown {
+		deadText := mutedStyle.Render(fmt.Sprintf("%4d", listener.dead))
+		if listener.dead > 0 {
+			deadText = deadStyle.Bold(true).Render(fmt.Sprintf("%4d", listener.dead))
+		}
+		lines = append(lines, fmt.Sprintf("%s %s %s %s",
+			labelStyle.Render(padText(truncateURLMiddle(listener.url, urlWidth), urlWidth)),
+			valueStyle.Render(fmt.Sprintf("%4d", listener.total)),
+			liveStyle.Render(fmt.Sprintf("%4d", listener.live)),
+			deadText))
+		
+		// Protocol mix, most used first
+		protos := make([]string, 0, len(listener.protocols))
+		for proto := range listener.protocols {
+			protos = append(protos, proto)
+		}
+		sort.Slice(protos, func(i, j int) bool {
+			ci, cj := listener.protocols[protos[i]], listener.protocols[protos[j]]
+			if ci != cj {
+				return ci > cj
 			}
-			lines = append(lines, fmt.Sprintf("   └─ %s",
-				mutedStyle.Render(strings.Join(protoList, ", "))))
-			lines = append(lines, "")
+			return protos[i] < protos[j]
+		})
+		var protoList []string
+		for _, proto := range protos {
+			protoList = append(protoList, fmt.Sprintf("%s:%d", proto, listener.protocols[proto]))
+		}
+		lines = append(lines, mutedStyle.Render(truncateText(" ├─ "+strings.Join(protoList, ", "), 32)))
+		
+		// Oldest / newest agent age
+		age := "age unknown"
+		if !listener.oldest.IsZero() {
+			age = fmt.Sprintf("oldest %s · newest %s",
+				formatDuration(time.Since(listener.oldest)),
+				formatDuration(time.Since(listener.newest)))
 		}
+		lines = append(lines, mutedStyle.Render(truncateText(" └─ "+age, 32)))
+	}
+	
+	if hidden := len(listeners) - len(shown); hidden > 0 {
+		lines = append(lines, mutedStyle.Render(fmt.Sprintf("+%d more listeners", hidden)))
 	}
 	
 	return panelStyle.Render(strings.Join(lines, "\n"))
@@ -2721,9 +6164,8 @@ func (m model) renderArchitecturePanel() string {
 	mutedStyle := lipgloss.NewStyle().
 		Foreground(m.theme.TacticalMuted)
 	
-	// Cyan bar style matching task queue
 	barStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#00CED1")) // Dark turquoise
+		Foreground(m.theme.StatsColor)
 	
 	// Privilege color styles
 	privStyle := lipgloss.NewStyle().
@@ -2804,9 +6246,7 @@ func (m model) renderArchitecturePanel() string {
 	}
 	
 	if totalAgents == 0 {
-		lines = append(lines, mutedStyle.Render("No agents available"))
-		lines = append(lines, "")
-		lines = append(lines, mutedStyle.Render("Waiting for connections..."))
+		return m.renderEmptyPanel(panelStyle, "💻 OS & PRIVILEGE MATRIX", "No live agents - waiting for connections")
 	} else {
 		// Summary stats at top
 		lines = append(lines, fmt.Sprintf("%s %s",
@@ -2892,9 +6332,8 @@ func (m model) renderNetworkTopologyPanel() string {
 	mutedStyle := lipgloss.NewStyle().
 		Foreground(m.theme.TacticalMuted)
 	
-	// Cyan bar style for subnet visualization
 	barStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#00CED1")) // Dark turquoise
+		Foreground(m.theme.StatsColor)
 	
 	// Clickable style
 	clickableStyle := lipgloss.NewStyle().
@@ -2908,6 +6347,7 @@ func (m model) renderNetworkTopologyPanel() string {
 	
 	// Group agents by subnet (first 3 octets) with deduplication by hostname
 	subnetHosts := make(map[string]map[string]Agent) // subnet -> hostname -> agent
+	subnetAgents := make(map[string][]Agent)         // subnet -> live agents (for counts)
 	pivotCount := 0
 	
 	for _, agent := range m.agents {
@@ -2915,24 +6355,16 @@ func (m model) renderNetworkTopologyPanel() string {
 			continue
 		}
 		
-		// Extract subnet from RemoteAddress (format: IP:Port)
-		ip := agent.RemoteAddress
-		if idx := strings.Index(ip, ":"); idx != -1 {
-			ip = ip[:idx] // Remove port
-		}
-		
-		// Get subnet (first 3 octets)
-		octets := strings.Split(ip, ".")
-		subnet := "unknown"
-		if len(octets) >= 3 {
-			subnet = fmt.Sprintf("%s.%s.%s.0/24", octets[0], octets[1], octets[2])
-		}
+		// Get subnet (first 3 octets, or the Unknown bucket)
+		subnet := extractSubnet(agent.RemoteAddress)
 		
 		// Initialize subnet map if not exists
 		if subnetHosts[subnet] == nil {
 			subnetHosts[subnet] = make(map[string]Agent)
 		}
 		
+		subnetAgents[subnet] = append(subnetAgents[subnet], agent)
+		
 		// Deduplicate by hostname (keep first occurrence)
 		if _, exists := subnetHosts[subnet][agent.Hostname]; !exists {
 			subnetHosts[subnet][agent.Hostname] = agent
@@ -2947,7 +6379,7 @@ func (m model) renderNetworkTopologyPanel() string {
 	totalSubnets := len(subnetHosts)
 	
 	if totalSubnets == 0 {
-		lines = append(lines, mutedStyle.Render("No network data available"))
+		return m.renderEmptyPanel(panelStyle, "🌍 NETWORK TOPOLOGY", "No subnets discovered yet")
 	} else {
 		// Use pre-built subnet order from model for numbered shortcuts
 		// This ensures consistent numbering across renders
@@ -2958,12 +6390,17 @@ func (m model) renderNetworkTopologyPanel() string {
 			valueStyle.Render(fmt.Sprintf("%d subnet(s)", totalSubnets))))
 		lines = append(lines, "")
 		
-		// Show each subnet (limit to 5 for space, but can handle unlimited via multi-digit input)
+		// Hostnames get the configured length, up to what the fixed-width
+		// panel fits beside the tree glyphs
+		topologyHostnameWidth := min(m.hostnameWidth(18), 22)
+		
+		// Show each subnet up to the configured/height-derived limit (the
+		// rest stay reachable via multi-digit input)
 		count := 0
-		maxVisible := 5
+		maxVisible := m.topologySubnetLimit()
 		for subnetIdx, subnet := range m.subnetOrder {
 			if count >= maxVisible {
-				remaining := totalSubnets - maxVisible
+				remaining := totalSubnets - count
 				if remaining > 0 {
 					lines = append(lines, mutedStyle.Render(fmt.Sprintf("... and %d more (type number to expand)", remaining)))
 				}
@@ -2990,6 +6427,8 @@ func (m model) renderNetworkTopologyPanel() string {
 				}
 			}
 			
+			subnetCount := m.countOf(subnetAgents[subnet], func(Agent) bool { return true })
+			
 			// Create mini bar for this subnet
 			barLength := len(agents)
 			if barLength > 10 {
@@ -3019,7 +6458,7 @@ func (m model) renderNetworkTopologyPanel() string {
 				labelStyle.Render(subnet)))
 			lines = append(lines, fmt.Sprintf("   %s %s",
 				barStyle.Render(bar),
-				valueStyle.Render(fmt.Sprintf("%d host(s)", len(agents)))))
+				valueStyle.Render(fmt.Sprintf("%d %s", subnetCount, m.countUnit(subnetCount)))))
 			
 			// Show individual hostnames based on expansion state
 			if isExpanded {
@@ -3038,10 +6477,7 @@ func (m model) renderNetworkTopologyPanel() string {
 					}
 					
 					// Truncate hostname if too long
-					hostname := agent.Hostname
-					if len(hostname) > 18 {
-						hostname = hostname[:15] + "..."
-					}
+					hostname := truncateText(agent.Hostname, topologyHostnameWidth)
 					
 					lines = append(lines, fmt.Sprintf("      %s %s %s",
 						mutedStyle.Render(hostIcon),
@@ -3074,10 +6510,7 @@ func (m model) renderNetworkTopologyPanel() string {
 					}
 					
 					// Truncate hostname if too long
-					hostname := agent.Hostname
-					if len(hostname) > 18 {
-						hostname = hostname[:15] + "..."
-					}
+					hostname := truncateText(agent.Hostname, topologyHostnameWidth)
 					
 					lines = append(lines, fmt.Sprintf("      %s %s %s",
 						mutedStyle.Render(hostIcon),
@@ -3103,14 +6536,33 @@ func (m model) renderNetworkTopologyPanel() string {
 	return panelStyle.Render(strings.Join(lines, "\n"))
 }
 
-// renderTaskQueuePanel shows beacon task queue status
-func (m model) renderTaskQueuePanel() string {
+// topologySubnetLimit returns how many subnets the Network Topology panel
+// lists: the topology_subnets pref, or as many collapsed subnets (number,
+// bar, up to 3 hosts plus "more", gap) as fit under the dashboard chrome
+func (m model) topologySubnetLimit() int {
+	if m.prefs != nil && m.prefs.TopologySubnets > 0 {
+		return m.prefs.TopologySubnets
+	}
+	const chromeLines = 20    // Header, page tabs, footer, panel border/padding, title rows
+	const linesPerSubnet = 7
+	return max(3, (m.termHeight-chromeLines)/linesPerSubnet)
+}
+
+// subnetHistogramBars is how many subnets get their own histogram bar; the
+// rest are summed into an "other" bar
+const subnetHistogramBars = 5
+
+// renderSubnetHistogramPanel draws live agents (or hosts, per the count
+// mode) per subnet as vertical bars, busiest subnet first, so the most
+// compromised subnets stand out at a glance. Bars carry the subnet numbers
+// used by the Network Topology panel and the number-key shortcuts.
+func (m model) renderSubnetHistogramPanel() string {
 	panelStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(m.theme.TacticalBorder).
 		Padding(1, 2).
 		Width(38).
-		Height(15)
+		Height(18)
 	
 	titleStyle := lipgloss.NewStyle().
 		Foreground(m.theme.TacticalBorder).
@@ -3121,59 +6573,208 @@ func (m model) renderTaskQueuePanel() string {
 		Foreground(m.theme.TacticalSection)
 	
 	valueStyle := lipgloss.NewStyle().
-		Foreground(m.theme.TacticalValue)
+		Foreground(m.theme.TacticalValue).
+		Bold(true)
 	
 	mutedStyle := lipgloss.NewStyle().
 		Foreground(m.theme.TacticalMuted)
 	
-	// Cyan bar style for task progress
 	barStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#00CED1")) // Dark turquoise
+		Foreground(m.theme.StatsColor)
+	
+	const title = "📊 AGENTS PER SUBNET"
+	
+	type subnetCount struct {
+		subnet string
+		count  int
+	}
+	groups, subnets := groupAgentsBySubnet(m.agents)
+	var counts []subnetCount
+	for _, subnet := range subnets {
+		count := m.countOf(groups[subnet].Agents, func(agent Agent) bool { return !agent.IsDead })
+		if count > 0 {
+			counts = append(counts, subnetCount{subnet, count})
+		}
+	}
+	if len(counts) == 0 {
+		return m.renderEmptyPanel(panelStyle, title, "No live agents in any subnet")
+	}
+	sort.SliceStable(counts, func(i, j int) bool { return counts[i].count > counts[j].count })
+	
+	// Top subnets, the rest summed into "other"
+	bars := counts
+	if len(counts) > subnetHistogramBars {
+		other := subnetCount{subnet: fmt.Sprintf("other (%d subnets)", len(counts)-subnetHistogramBars)}
+		for _, c := range counts[subnetHistogramBars:] {
+			other.count += c.count
+		}
+		bars = append(append([]subnetCount{}, counts[:subnetHistogramBars]...), other)
+	}
+	
+	peak := 0
+	for _, bar := range bars {
+		if bar.count > peak {
+			peak = bar.count
+		}
+	}
 	
 	var lines []string
-	lines = append(lines, titleStyle.Render("📋 TASK QUEUE MONITOR"))
+	lines = append(lines, titleStyle.Render(title))
 	lines = append(lines, "")
 	
-	// Find beacons with tasks
-	beaconsWithTasks := 0
-	for _, agent := range m.agents {
-		if !agent.IsSession && agent.TasksCount > 0 {
-			beaconsWithTasks++
-			
-			// Show task progress
-			percentage := float64(0)
-			if agent.TasksCount > 0 {
-				percentage = float64(agent.TasksCompleted) / float64(agent.TasksCount) * 100
-			}
-			
-			barLength := int(percentage / 10) // 10% per block
-			if barLength > 10 {
-				barLength = 10
-			}
-			bar := strings.Repeat("█", barLength) + strings.Repeat("░", 10-barLength)
-			
-			// Status icon
-			statusIcon := "📋"
-			if percentage == 100 {
-				statusIcon = "✅"
-			} else if percentage < 30 {
-				statusIcon = "⚠️"
-			}
-			
-			lines = append(lines, fmt.Sprintf("%s %s",
-				statusIcon,
-				labelStyle.Render(fmt.Sprintf("%-15s", agent.Hostname[:min(15, len(agent.Hostname))]))))
-			lines = append(lines, fmt.Sprintf("  %s %s",
-				barStyle.Render(bar),
-				valueStyle.Render(fmt.Sprintf("%d/%d", agent.TasksCompleted, agent.TasksCount))))
-			
-			if beaconsWithTasks >= 5 {
-				break // Limit to 5 beacons for space
+	// Bars grow up from the axis in eighth steps of the sparkline ramp (the
+	// space above a bar stays blank rather than the ramp's empty cell)
+	const rows = 6
+	levels := append([]string{" "}, m.sparklineRamp()[1:]...)
+	for row := rows - 1; row >= 0; row-- {
+		axisLabel := "   "
+		if row == rows-1 {
+			axisLabel = fmt.Sprintf("%3d", peak)
+		}
+		line := mutedStyle.Render(axisLabel + "┤")
+		for i, bar := range bars {
+			fill := bar.count*rows*8/peak - row*8
+			cell := levels[max(min(fill, 8), 0)]
+			style := barStyle
+			if i == subnetHistogramBars {
+				style = mutedStyle // "other"
 			}
+			line += " " + style.Render(strings.Repeat(cell, 3))
 		}
+		lines = append(lines, line)
 	}
+	lines = append(lines, mutedStyle.Render("  0└"+strings.Repeat("─", 4*len(bars))))
 	
-	if beaconsWithTasks == 0 {
+	// X-axis: subnet numbers (as in the topology panel), keyed to the subnets below
+	subnetNumbers := make(map[string]int, len(m.subnetOrder))
+	for i, subnet := range m.subnetOrder {
+		subnetNumbers[subnet] = i + 1
+	}
+	keys := make([]string, len(bars))
+	for i, bar := range bars {
+		if i == subnetHistogramBars {
+			keys[i] = "oth"
+		} else if number, ok := subnetNumbers[bar.subnet]; ok {
+			keys[i] = strconv.Itoa(number)
+		} else {
+			keys[i] = "-"
+		}
+	}
+	axis := "    "
+	for _, key := range keys {
+		axis += fmt.Sprintf(" %-3s", key)
+	}
+	lines = append(lines, mutedStyle.Render(axis))
+	
+	for i, bar := range bars {
+		lines = append(lines, fmt.Sprintf("%s %s %s",
+			mutedStyle.Render(padText(keys[i], 3)),
+			labelStyle.Render(padText(truncateText(bar.subnet, 22), 22)),
+			valueStyle.Render(fmt.Sprintf("%d", bar.count))))
+	}
+	
+	return panelStyle.Render(strings.Join(lines, "\n"))
+}
+
+// renderTaskQueuePanel shows beacon task queue status
+func (m model) renderTaskQueuePanel() string {
+	panelStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.theme.TacticalBorder).
+		Padding(1, 2).
+		Width(38).
+		Height(15)
+	
+	titleStyle := lipgloss.NewStyle().
+		Foreground(m.theme.TacticalBorder).
+		Bold(true).
+		Underline(true)
+	
+	labelStyle := lipgloss.NewStyle().
+		Foreground(m.theme.TacticalSection)
+	
+	valueStyle := lipgloss.NewStyle().
+		Foreground(m.theme.TacticalValue)
+	
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(m.theme.TacticalMuted)
+	
+	barStyle := lipgloss.NewStyle().
+		Foreground(m.theme.StatsColor)
+	
+	stalledStyle := lipgloss.NewStyle().
+		Foreground(m.theme.WarningColor).
+		Bold(true)
+	
+	taskSort := config.TaskSortPending
+	if m.prefs != nil && m.prefs.TaskSort != "" {
+		taskSort = m.prefs.TaskSort
+	}
+	
+	var lines []string
+	lines = append(lines, titleStyle.Render("📋 TASK QUEUE MONITOR")+" "+mutedStyle.Render("↓"+taskSort))
+	stalled := m.countOf(m.agents, func(agent Agent) bool { return m.stalledTasks(agent) > 0 })
+	if stalled > 0 {
+		lines = append(lines, stalledStyle.Render(fmt.Sprintf("⛔ %d %s with tasks stuck pending", stalled, m.countUnit(stalled))))
+	} else {
+		lines = append(lines, "")
+	}
+	
+	// Beacons with tasks, most relevant first (stable across refreshes)
+	var tasked []Agent
+	for _, agent := range m.agents {
+		if !agent.IsSession && agent.TasksCount > 0 {
+			tasked = append(tasked, agent)
+		}
+	}
+	sortTaskedBeacons(tasked, taskSort)
+	
+	beaconsWithTasks := 0
+	for _, agent := range tasked {
+		beaconsWithTasks++
+		
+		// Show task progress
+		percentage := float64(0)
+		if agent.TasksCount > 0 {
+			percentage = float64(agent.TasksCompleted) / float64(agent.TasksCount) * 100
+		}
+		
+		barLength := int(percentage / 10) // 10% per block
+		if barLength > 10 {
+			barLength = 10
+		}
+		bar := strings.Repeat("█", barLength) + strings.Repeat("░", 10-barLength)
+		
+		// Status icon
+		statusIcon := "📋"
+		if percentage == 100 {
+			statusIcon = "✅"
+		} else if percentage < 30 {
+			statusIcon = "⚠️"
+		}
+		stalledBadge := ""
+		if stuck := m.stalledTasks(agent); stuck > 0 {
+			statusIcon = "⛔"
+			stalledBadge = " " + stalledStyle.Render(fmt.Sprintf("STALLED (%d)", stuck))
+		}
+		
+		lines = append(lines, fmt.Sprintf("%s %s%s",
+			statusIcon,
+			labelStyle.Render(padText(truncateText(agent.Hostname, 15), 15)),
+			stalledBadge))
+		lines = append(lines, fmt.Sprintf("  %s %s",
+			barStyle.Render(bar),
+			valueStyle.Render(fmt.Sprintf("%d/%d", agent.TasksCompleted, agent.TasksCount))))
+		
+		if beaconsWithTasks >= 5 {
+			break // Limit to 5 beacons for space
+		}
+	}
+	
+	if beaconsWithTasks == 0 && m.countOf(m.agents, func(agent Agent) bool { return !agent.IsSession }) == 0 {
+		return m.renderEmptyPanel(panelStyle, "📋 TASK QUEUE MONITOR", "No beacons connected")
+	}
+	if beaconsWithTasks == 0 {
 		lines = append(lines, mutedStyle.Render("No active beacon tasks"))
 		lines = append(lines, "")
 		lines = append(lines, mutedStyle.Render("All beacons are idle 💤"))
@@ -3182,6 +6783,251 @@ func (m model) renderTaskQueuePanel() string {
 	return panelStyle.Render(strings.Join(lines, "\n"))
 }
 
+// sortTaskedBeacons orders beacons for the Task Queue Monitor by a
+// config.TaskSort* key, breaking ties by hostname then ID
+func sortTaskedBeacons(beacons []Agent, taskSort string) {
+	pending := func(agent Agent) int64 { return agent.TasksCount - agent.TasksCompleted }
+	sort.SliceStable(beacons, func(i, j int) bool {
+		a, b := beacons[i], beacons[j]
+		switch taskSort {
+		case config.TaskSortCompletion:
+			// Fewest tasks left first; fully done beacons last
+			if (pending(a) == 0) != (pending(b) == 0) {
+				return pending(b) == 0
+			}
+			if pending(a) != pending(b) {
+				return pending(a) < pending(b)
+			}
+		case config.TaskSortCheckin:
+			// Soonest next check-in first; unknown check-ins last
+			if (a.NextCheckin == 0) != (b.NextCheckin == 0) {
+				return b.NextCheckin == 0
+			}
+			if a.NextCheckin != b.NextCheckin {
+				return a.NextCheckin < b.NextCheckin
+			}
+		default:
+			if pending(a) != pending(b) {
+				return pending(a) > pending(b)
+			}
+		}
+		if a.Hostname != b.Hostname {
+			return a.Hostname < b.Hostname
+		}
+		return a.ID < b.ID
+	})
+}
+
+// promotionCandidate is a beacon ranked for interactive (session) work
+type promotionCandidate struct {
+	agent   Agent
+	score   int
+	reasons []string
+}
+
+// rankPromotionCandidates scores live beacons by privilege, check-in
+// reliability, interval and recency (highest score first)
+func rankPromotionCandidates(agents []Agent) []promotionCandidate {
+	var candidates []promotionCandidate
+	now := time.Now()
+	
+	for _, agent := range agents {
+		if agent.IsSession || agent.IsDead {
+			continue // Only live beacons can be promoted
+		}
+		
+		candidate := promotionCandidate{agent: agent}
+		interval := time.Duration(agent.Interval) // Nanoseconds
+		
+		if agent.IsPrivileged {
+			candidate.score += 3
+			candidate.reasons = append(candidate.reasons, "privileged")
+		}
+		
+		// Reliable: last check-in is within the expected interval (+jitter, +50% slack)
+		if agent.LastCheckin > 0 && interval > 0 {
+			sinceCheckin := now.Sub(time.Unix(agent.LastCheckin, 0))
+			if sinceCheckin <= interval+time.Duration(agent.Jitter)+interval/2 {
+				candidate.score += 2
+				candidate.reasons = append(candidate.reasons, "reliable")
+			}
+		}
+		
+		// Fast intervals mean quick task turnaround
+		if interval > 0 && interval <= time.Minute {
+			candidate.score += 2
+			candidate.reasons = append(candidate.reasons, "fast interval")
+		} else if interval > 0 && interval <= 5*time.Minute {
+			candidate.score++
+		}
+		
+		if agent.IsNew {
+			candidate.score++
+			candidate.reasons = append(candidate.reasons, "recent")
+		}
+		
+		if candidate.score > 0 {
+			candidates = append(candidates, candidate)
+		}
+	}
+	
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score > candidates[j].score
+		}
+		return candidates[i].agent.Hostname < candidates[j].agent.Hostname
+	})
+	
+	return candidates
+}
+
+// renderPromotionPanel lists beacons that are good candidates for a session
+// (informational only - the TUI never tasks agents)
+func (m model) renderPromotionPanel() string {
+	panelStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.theme.TacticalBorder).
+		Padding(1, 2).
+		Width(38).
+		Height(15)
+	
+	titleStyle := lipgloss.NewStyle().
+		Foreground(m.theme.TacticalBorder).
+		Bold(true).
+		Underline(true)
+	
+	labelStyle := lipgloss.NewStyle().
+		Foreground(m.theme.TacticalSection)
+	
+	valueStyle := lipgloss.NewStyle().
+		Foreground(m.theme.TacticalValue).
+		Bold(true)
+	
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(m.theme.TacticalMuted)
+	
+	var lines []string
+	lines = append(lines, titleStyle.Render("🚀 READY TO PROMOTE"))
+	lines = append(lines, "")
+	
+	candidates := rankPromotionCandidates(m.agents)
+	
+	if len(candidates) == 0 {
+		lines = append(lines, mutedStyle.Render("No beacon candidates"))
+		lines = append(lines, "")
+		lines = append(lines, mutedStyle.Render("Waiting for live beacons..."))
+		return panelStyle.Render(strings.Join(lines, "\n"))
+	}
+	
+	maxVisible := 5
+	for i, candidate := range candidates {
+		if i >= maxVisible {
+			lines = append(lines, mutedStyle.Render(fmt.Sprintf("... and %d more", len(candidates)-maxVisible)))
+			break
+		}
+		
+		hostname := truncateText(candidate.agent.Hostname, 20)
+		
+		lines = append(lines, fmt.Sprintf("%s %s %s",
+			mutedStyle.Render(fmt.Sprintf("%d.", i+1)),
+			labelStyle.Render(padText(hostname, 20)),
+			valueStyle.Render(fmt.Sprintf("★%d", candidate.score))))
+		
+		reasons := strings.Join(candidate.reasons, ", ")
+		if reasons == "" {
+			reasons = "steady interval"
+		}
+		lines = append(lines, mutedStyle.Render("   "+reasons))
+	}
+	
+	return panelStyle.Render(strings.Join(lines, "\n"))
+}
+
+// formatBytes formats a byte count with a binary unit ("512 B", "1.5 KB", "3.2 MB")
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	value := float64(n) / unit
+	for _, suffix := range []string{"KB", "MB", "GB"} {
+		if value < unit || suffix == "GB" {
+			return fmt.Sprintf("%.1f %s", value, suffix)
+		}
+		value /= unit
+	}
+	return ""
+}
+
+// renderTrafficPanel ranks live beacons by estimated check-in traffic so
+// noisy (OPSEC-risky) implants stand out. Sliver reports no byte counts, so
+// the rate is derived from the check-in interval; task volume is shown
+// beneath each beacon and only breaks ties.
+func (m model) renderTrafficPanel() string {
+	panelStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.theme.TacticalBorder).
+		Padding(1, 2).
+		Width(38).
+		Height(15)
+	
+	titleStyle := lipgloss.NewStyle().
+		Foreground(m.theme.TacticalBorder).
+		Bold(true).
+		Underline(true)
+	
+	labelStyle := lipgloss.NewStyle().
+		Foreground(m.theme.TacticalSection)
+	
+	valueStyle := lipgloss.NewStyle().
+		Foreground(m.theme.TacticalValue).
+		Bold(true)
+	
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(m.theme.TacticalMuted)
+	
+	var lines []string
+	lines = append(lines, titleStyle.Render("📶 CHATTY BEACONS"))
+	lines = append(lines, mutedStyle.Render("Check-in traffic, from interval"))
+	lines = append(lines, "")
+	
+	var beacons []Agent
+	for _, agent := range m.agents {
+		if !agent.IsSession && !agent.IsDead && agent.TrafficPerHour > 0 {
+			beacons = append(beacons, agent)
+		}
+	}
+	if len(beacons) == 0 {
+		lines = append(lines, mutedStyle.Render("No live beacons"))
+		return panelStyle.Render(strings.Join(lines, "\n"))
+	}
+	
+	// Chattiest first; task volume breaks ties
+	sort.SliceStable(beacons, func(i, j int) bool {
+		if beacons[i].TrafficPerHour != beacons[j].TrafficPerHour {
+			return beacons[i].TrafficPerHour > beacons[j].TrafficPerHour
+		}
+		return beacons[i].TasksCount > beacons[j].TasksCount
+	})
+	
+	maxVisible := 5
+	for i, agent := range beacons {
+		if i >= maxVisible {
+			lines = append(lines, mutedStyle.Render(fmt.Sprintf("... and %d more", len(beacons)-maxVisible)))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("%s %s %s",
+			mutedStyle.Render(fmt.Sprintf("%d.", i+1)),
+			labelStyle.Render(padText(truncateText(agent.Hostname, 16), 16)),
+			valueStyle.Render("~"+formatBytes(agent.TrafficPerHour)+"/h")))
+		lines = append(lines, mutedStyle.Render(fmt.Sprintf("   every %s, %d tasks (~%s)",
+			formatAge(time.Duration(agent.Interval)), agent.TasksCount,
+			formatBytes(agent.TasksCount*client.BeaconTaskBytes))))
+	}
+	
+	return panelStyle.Render(strings.Join(lines, "\n"))
+}
+
 // renderSecurityStatusPanel shows agent security states
 func (m model) renderSecurityStatusPanel() string {
 	panelStyle := lipgloss.NewStyle().
@@ -3207,6 +7053,10 @@ func (m model) renderSecurityStatusPanel() string {
 		Foreground(lipgloss.Color("#FF4500")). // Orange red
 		Bold(true)
 	
+	duplicateStyle := lipgloss.NewStyle().
+		Foreground(m.theme.WarningColor).
+		Bold(true)
+	
 	mutedStyle := lipgloss.NewStyle().
 		Foreground(m.theme.TacticalMuted)
 	
@@ -3263,11 +7113,271 @@ func (m model) renderSecurityStatusPanel() string {
 		lines = append(lines, "")
 	}
 	
-	// Show normal status if no special states
-	if len(stealthAgents) == 0 && len(burnedAgents) == 0 {
-		lines = append(lines, mutedStyle.Render("All agents operating normally"))
-		lines = append(lines, "")
-		lines = append(lines, labelStyle.Render(fmt.Sprintf("✓ %d agents in standard mode", normalAgents)))
+	// Show duplicate PIDs (same host + PID registered more than once)
+	duplicateHosts := make(map[string]int)
+	for _, agent := range m.agents {
+		if m.duplicatePIDs[agent.ID] {
+			duplicateHosts[fmt.Sprintf("%s (PID %d)", agent.Hostname, agent.PID)]++
+		}
+	}
+	if len(duplicateHosts) > 0 {
+		dupKeys := make([]string, 0, len(duplicateHosts))
+		for key := range duplicateHosts {
+			dupKeys = append(dupKeys, key)
+		}
+		sort.Strings(dupKeys)
+		
+		lines = append(lines, duplicateStyle.Render("⚠  DUPLICATE PIDS"))
+		lines = append(lines, mutedStyle.Render(fmt.Sprintf("   %d host/PID pair(s) re-registered", len(dupKeys))))
+		lines = append(lines, "")
+		for i, key := range dupKeys {
+			if i >= 3 {
+				lines = append(lines, mutedStyle.Render(fmt.Sprintf("   ... and %d more", len(dupKeys)-3)))
+				break
+			}
+			lines = append(lines, labelStyle.Render(fmt.Sprintf("   • %s ×%d", key, duplicateHosts[key])))
+		}
+		lines = append(lines, "")
+	}
+	
+	// Show version skew (agents off the fleet's most common build)
+	skewedByVersion := make(map[string]int)
+	unknownVersions := 0
+	for _, agent := range m.agents {
+		if m.skewedVersions[agent.ID] {
+			skewedByVersion[agent.Version]++
+		} else if agent.Version == "" {
+			unknownVersions++
+		}
+	}
+	if len(skewedByVersion) > 0 || unknownVersions > 0 {
+		lines = append(lines, duplicateStyle.Render("⇩  VERSION SKEW"))
+		if len(skewedByVersion) > 0 {
+			versions := make([]string, 0, len(skewedByVersion))
+			skewedTotal := 0
+			for version, count := range skewedByVersion {
+				versions = append(versions, version)
+				skewedTotal += count
+			}
+			sort.Strings(versions)
+			summary := fmt.Sprintf("   %d agent(s) on old build %s", skewedTotal, versions[0])
+			if len(versions) > 1 {
+				summary = fmt.Sprintf("   %d agent(s) on %d old builds", skewedTotal, len(versions))
+			}
+			lines = append(lines, mutedStyle.Render(summary))
+			lines = append(lines, mutedStyle.Render(fmt.Sprintf("   fleet build: %s", m.modalVersion)))
+		}
+		if unknownVersions > 0 {
+			lines = append(lines, mutedStyle.Render(fmt.Sprintf("   %d agent(s) with unknown version", unknownVersions)))
+		}
+		lines = append(lines, "")
+	}
+	
+	if normalAgents == 0 && len(stealthAgents) == 0 && len(burnedAgents) == 0 {
+		return m.renderEmptyPanel(panelStyle, "🔒 SECURITY STATUS", "No live agents to assess")
+	}
+	
+	// Show normal status if no special states
+	if len(stealthAgents) == 0 && len(burnedAgents) == 0 && len(duplicateHosts) == 0 && len(skewedByVersion) == 0 {
+		lines = append(lines, mutedStyle.Render("All agents operating normally"))
+		lines = append(lines, "")
+		lines = append(lines, labelStyle.Render(fmt.Sprintf("✓ %d agents in standard mode", normalAgents)))
+	}
+	
+	return panelStyle.Render(strings.Join(lines, "\n"))
+}
+
+// renderCompromisedUsersPanel counts distinct compromised accounts
+// (user@domain), split into privileged and standard
+func (m model) renderCompromisedUsersPanel() string {
+	panelStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.theme.TacticalBorder).
+		Padding(1, 2).
+		Width(38).
+		Height(15)
+	
+	titleStyle := lipgloss.NewStyle().
+		Foreground(m.theme.TacticalBorder).
+		Bold(true).
+		Underline(true)
+	
+	labelStyle := lipgloss.NewStyle().
+		Foreground(m.theme.TacticalSection)
+	
+	valueStyle := lipgloss.NewStyle().
+		Foreground(m.theme.TacticalValue).
+		Bold(true)
+	
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(m.theme.TacticalMuted)
+	
+	privStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#ff5555")). // Red for privileged
+		Bold(true)
+	
+	userStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#50fa7b")) // Green for user-level
+	
+	var lines []string
+	lines = append(lines, titleStyle.Render("👥 COMPROMISED USERS"))
+	lines = append(lines, "")
+	
+	// identity -> privileged (true if any agent running as it is privileged)
+	identities := make(map[string]bool)
+	for _, agent := range m.agents {
+		identity := userIdentity(agent)
+		if identity == "" {
+			continue
+		}
+		identities[identity] = identities[identity] || agent.IsPrivileged
+	}
+	
+	if len(identities) == 0 {
+		lines = append(lines, mutedStyle.Render("No users compromised"))
+		lines = append(lines, "")
+		lines = append(lines, mutedStyle.Render("Waiting for connections..."))
+		return panelStyle.Render(strings.Join(lines, "\n"))
+	}
+	
+	// Privileged accounts first, then alphabetical
+	names := make([]string, 0, len(identities))
+	privilegedCount := 0
+	for identity, privileged := range identities {
+		names = append(names, identity)
+		if privileged {
+			privilegedCount++
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if identities[names[i]] != identities[names[j]] {
+			return identities[names[i]]
+		}
+		return names[i] < names[j]
+	})
+	
+	lines = append(lines, fmt.Sprintf("%s %s",
+		labelStyle.Render("Unique Accounts:"),
+		valueStyle.Render(fmt.Sprintf("%d", len(names)))))
+	lines = append(lines, fmt.Sprintf("%s %s | %s",
+		mutedStyle.Render("└─"),
+		privStyle.Render(fmt.Sprintf("💎 %d priv", privilegedCount)),
+		userStyle.Render(fmt.Sprintf("👤 %d std", len(names)-privilegedCount))))
+	lines = append(lines, "")
+	
+	maxVisible := 6
+	for i, name := range names {
+		if i >= maxVisible {
+			lines = append(lines, mutedStyle.Render(fmt.Sprintf("... and %d more", len(names)-maxVisible)))
+			break
+		}
+		icon := "👤"
+		if identities[name] {
+			icon = "💎"
+		}
+		lines = append(lines, fmt.Sprintf("%s %s", icon, labelStyle.Render(truncateText(name, 30))))
+	}
+	
+	return panelStyle.Render(strings.Join(lines, "\n"))
+}
+
+// renderProcessPanel groups live agents by process name so operators can
+// review how implants are masquerading (e.g. everything as svchost.exe)
+func (m model) renderProcessPanel() string {
+	panelStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.theme.TacticalBorder).
+		Padding(1, 2).
+		Width(38).
+		Height(18)
+	
+	titleStyle := lipgloss.NewStyle().
+		Foreground(m.theme.TacticalBorder).
+		Bold(true).
+		Underline(true)
+	
+	labelStyle := lipgloss.NewStyle().
+		Foreground(m.theme.TacticalSection)
+	
+	valueStyle := lipgloss.NewStyle().
+		Foreground(m.theme.TacticalValue).
+		Bold(true)
+	
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(m.theme.TacticalMuted)
+	
+	barStyle := lipgloss.NewStyle().
+		Foreground(m.theme.StatsColor)
+	
+	var lines []string
+	lines = append(lines, titleStyle.Render("🧬 PROCESS NAMES"))
+	lines = append(lines, "")
+	
+	// Group by basename, case-insensitive (Windows paths are)
+	type processGroup struct {
+		name  string
+		count int
+	}
+	groups := make(map[string]*processGroup)
+	totalAgents := 0
+	
+	for _, agent := range m.agents {
+		if agent.IsDead {
+			continue // Skip dead agents
+		}
+		
+		name := extractFilename(agent.Filename)
+		if name == "" {
+			name = "unknown"
+		}
+		key := strings.ToLower(name)
+		if groups[key] == nil {
+			groups[key] = &processGroup{name: name}
+		}
+		groups[key].count++
+		totalAgents++
+	}
+	
+	if totalAgents == 0 {
+		return m.renderEmptyPanel(panelStyle, "🧬 PROCESS NAMES", "No live agents to group")
+	}
+	
+	// Most common process first, then alphabetical for stable output
+	sorted := make([]*processGroup, 0, len(groups))
+	for _, group := range groups {
+		sorted = append(sorted, group)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].count != sorted[j].count {
+			return sorted[i].count > sorted[j].count
+		}
+		return strings.ToLower(sorted[i].name) < strings.ToLower(sorted[j].name)
+	})
+	
+	lines = append(lines, fmt.Sprintf("%s %s",
+		labelStyle.Render("Distinct Names:"),
+		valueStyle.Render(fmt.Sprintf("%d", len(sorted)))))
+	lines = append(lines, "")
+	
+	maxVisible := 5
+	for i, group := range sorted {
+		if i >= maxVisible {
+			lines = append(lines, mutedStyle.Render(fmt.Sprintf("... and %d more", len(sorted)-maxVisible)))
+			break
+		}
+		
+		name := truncateText(group.name, 22)
+		
+		barLength := group.count * 10 / totalAgents
+		if barLength < 1 {
+			barLength = 1
+		}
+		bar := strings.Repeat("█", barLength) + strings.Repeat("░", 10-barLength)
+		
+		lines = append(lines, fmt.Sprintf("%s %s",
+			labelStyle.Render(padText(name, 22)),
+			valueStyle.Render(fmt.Sprintf("%d", group.count))))
+		lines = append(lines, fmt.Sprintf("  %s", barStyle.Render(bar)))
 	}
 	
 	return panelStyle.Render(strings.Join(lines, "\n"))
@@ -3305,76 +7415,74 @@ func (m model) renderSparklinePanel() string {
 	
 	// Title with session duration
 	durationStr := formatDuration(sessionDuration)
-	lines = append(lines, titleStyle.Render("ACTIVITY METRICS (Last 12 Hours)"))
-	lines = append(lines, mutedStyle.Render(fmt.Sprintf("Session: %s | Samples: %d/72", 
-		durationStr, len(samples))))
+	title := fmt.Sprintf("ACTIVITY METRICS (Last %s)", m.activityWindow())
+	lines = append(lines, titleStyle.Render(title))
+	lines = append(lines, mutedStyle.Render(fmt.Sprintf("Session: %s | Samples: %d/%d", 
+		durationStr, len(samples), m.activityTracker.MaxSamples)))
 	lines = append(lines, "")
 	
 	sparklineWidth := 28 // Adjusted width for narrower panel (38 char panel)
 	
 	if len(samples) == 0 {
-		lines = append(lines, mutedStyle.Render("Collecting data... (first sample in 10min)"))
-		return panelStyle.Render(strings.Join(lines, "\n"))
+		return m.renderEmptyPanel(panelStyle, title, m.activityEmptyMessage())
 	}
 	
 	// Calculate statistics
 	stats := calculateActivityStats(samples)
 	
 	// Use cached sparklines if available and samples haven't changed
-	var sessionsSparkline, beaconsSparkline, newSparkline, privilegedSparkline, timeAxis string
-	if m.sparklineCache.lastSampleCount == len(samples) && 
-	   time.Since(m.sparklineCache.lastUpdate) < 30*time.Second {
-		// Use cached sparklines
-		sessionsSparkline = m.sparklineCache.sessionSparkline
-		beaconsSparkline = m.sparklineCache.beaconSparkline
-		newSparkline = m.sparklineCache.newAgentsSparkline
-		privilegedSparkline = m.sparklineCache.privilegedSparkline
+	var timeAxis string
+	ramp := m.sparklineRamp()
+	cached := m.sparklineCache.sparklines != nil && m.sparklineCache.lastSampleCount == len(samples) && m.sparklineCache.ramp == ramp[len(ramp)-1] &&
+	   time.Since(m.sparklineCache.lastUpdate) < 30*time.Second
+	if cached {
 		timeAxis = m.sparklineCache.timeAxis
 	} else {
-		// Generate new sparklines and cache them
-		sessionsSparkline = generateHistoricalSparkline(samples, "sessions", sparklineWidth)
-		beaconsSparkline = generateHistoricalSparkline(samples, "beacons", sparklineWidth)
-		newSparkline = generateHistoricalSparkline(samples, "new", sparklineWidth)
-		privilegedSparkline = generateHistoricalSparkline(samples, "privileged", sparklineWidth)
 		timeAxis = generateTimeAxis(samples, sparklineWidth, m.activityTracker.StartTime)
-		
-		// Update cache
-		m.sparklineCache.sessionSparkline = sessionsSparkline
-		m.sparklineCache.beaconSparkline = beaconsSparkline
-		m.sparklineCache.newAgentsSparkline = newSparkline
-		m.sparklineCache.privilegedSparkline = privilegedSparkline
+		m.sparklineCache.sparklines = make(map[string]string)
 		m.sparklineCache.timeAxis = timeAxis
 		m.sparklineCache.lastSampleCount = len(samples)
+		m.sparklineCache.ramp = ramp[len(ramp)-1]
 		m.sparklineCache.lastUpdate = time.Now()
 	}
 	
-	// Sessions
-	lines = append(lines, fmt.Sprintf("%s  %s  Peak: %-2d  Now: %-2d",
-		labelStyle.Render("Sessions    "),
-		sparklineStyle.Render(sessionsSparkline),
-		stats.SessionsPeak,
-		stats.SessionsCurrent))
-	
-	// Beacons
-	lines = append(lines, fmt.Sprintf("%s  %s  Peak: %-2d  Now: %-2d",
-		labelStyle.Render("Beacons     "),
-		sparklineStyle.Render(beaconsSparkline),
-		stats.BeaconsPeak,
-		stats.BeaconsCurrent))
-	
-	// New Agents
-	lines = append(lines, fmt.Sprintf("%s  %s  Peak: %-2d  Now: %-2d",
-		labelStyle.Render("New Agents  "),
-		sparklineStyle.Render(newSparkline),
-		stats.NewPeak,
-		stats.NewCurrent))
-	
-	// Privileged
-	lines = append(lines, fmt.Sprintf("%s  %s  Peak: %-2d  Now: %-2d",
-		labelStyle.Render("Privileged  "),
-		sparklineStyle.Render(privilegedSparkline),
-		stats.PrivilegedPeak,
-		stats.PrivilegedCurrent))
+	// One row per configured metric (prefs sparkline_metrics)
+	metrics := m.sparklineMetrics
+	if len(metrics) == 0 {
+		metrics = defaultSparklineMetrics
+	}
+	for _, metric := range metrics {
+		sparkline, ok := m.sparklineCache.sparklines[metric]
+		if !cached || !ok {
+			sparkline = generateHistoricalSparkline(samples, metric, sparklineWidth, ramp)
+			m.sparklineCache.sparklines[metric] = sparkline
+		}
+		label := labelStyle.Render(padText(sparklineMetricLabel(metric), 12))
+		
+		// Compromise rate (new agents per hour; trailing-hour sparkline)
+		if metric == "rate" {
+			rateText := "—"
+			if stats.CompromiseRate >= 0 {
+				rateText = fmt.Sprintf("%.1f/h", stats.CompromiseRate)
+			}
+			lines = append(lines, fmt.Sprintf("%s  %s  %s",
+				label,
+				sparklineStyle.Render(sparkline),
+				lipgloss.NewStyle().Foreground(m.theme.TacticalValue).Bold(true).Render(rateText)))
+			continue
+		}
+		
+		peak, now := 0, 0
+		for i := range samples {
+			now = sampleMetricValue(samples, i, metric)
+			peak = max(peak, now)
+		}
+		lines = append(lines, fmt.Sprintf("%s  %s  Peak: %-2d  Now: %-2d",
+			label,
+			sparklineStyle.Render(sparkline),
+			peak,
+			now))
+	}
 	
 	lines = append(lines, "")
 	
@@ -3406,8 +7514,13 @@ type ActivityStats struct {
 	PrivilegedPeak   int
 	PrivilegedCurrent int
 	PrivilegedAvg    float64
+	CompromiseRate   float64 // Agent arrivals per hour over the window (-1 until enough samples)
 }
 
+// minRateSamples is how many samples the compromise rate needs before it's
+// shown (fewer make the per-hour figure meaningless)
+const minRateSamples = 3
+
 // calculateActivityStats calculates statistics from samples
 func calculateActivityStats(samples []ActivitySample) ActivityStats {
 	if len(samples) == 0 {
@@ -3415,7 +7528,7 @@ func calculateActivityStats(samples []ActivitySample) ActivityStats {
 	}
 	
 	stats := ActivityStats{}
-	var sessionsSum, beaconsSum, newSum, privilegedSum int
+	var sessionsSum, beaconsSum, newSum, privilegedSum, arrivals int
 	
 	for i, sample := range samples {
 		// Track peaks
@@ -3437,6 +7550,9 @@ func calculateActivityStats(samples []ActivitySample) ActivityStats {
 		beaconsSum += sample.BeaconsCount
 		newSum += sample.NewCount
 		privilegedSum += sample.PrivilegedCount
+		if i > 0 {
+			arrivals += sample.Arrivals // The first sample's arrivals predate the window
+		}
 		
 		// Current (last sample)
 		if i == len(samples)-1 {
@@ -3453,31 +7569,31 @@ func calculateActivityStats(samples []ActivitySample) ActivityStats {
 	stats.NewAvg = float64(newSum) / count
 	stats.PrivilegedAvg = float64(privilegedSum) / count
 	
+	// Compromise rate from distinct arrivals over the sampled window (NewCount
+	// would count an agent in every sample taken while it is flagged NEW)
+	stats.CompromiseRate = -1
+	if len(samples) >= minRateSamples {
+		window := samples[len(samples)-1].Timestamp.Sub(samples[0].Timestamp)
+		if window > 0 {
+			stats.CompromiseRate = float64(arrivals) / window.Hours()
+		}
+	}
+	
 	return stats
 }
 
 // generateHistoricalSparkline generates sparkline from historical samples
-func generateHistoricalSparkline(samples []ActivitySample, metric string, width int) string {
+func generateHistoricalSparkline(samples []ActivitySample, metric string, width int, ramp []string) string {
 	if len(samples) == 0 {
-		return strings.Repeat("░", width)
+		return strings.Repeat(ramp[0], width)
 	}
 	
 	// Extract values for the specified metric
 	values := make([]int, len(samples))
 	maxValue := 0
 	
-	for i, sample := range samples {
-		var value int
-		switch metric {
-		case "sessions":
-			value = sample.SessionsCount
-		case "beacons":
-			value = sample.BeaconsCount
-		case "new":
-			value = sample.NewCount
-		case "privileged":
-			value = sample.PrivilegedCount
-		}
+	for i := range samples {
+		value := sampleMetricValue(samples, i, metric)
 		values[i] = value
 		if value > maxValue {
 			maxValue = value
@@ -3485,7 +7601,7 @@ func generateHistoricalSparkline(samples []ActivitySample, metric string, width
 	}
 	
 	if maxValue == 0 {
-		return strings.Repeat("░", width)
+		return strings.Repeat(ramp[0], width)
 	}
 	
 	// Map samples to sparkline width (interpolation if needed)
@@ -3494,11 +7610,11 @@ func generateHistoricalSparkline(samples []ActivitySample, metric string, width
 	if len(samples) <= width {
 		// Fewer samples than width - pad with empty space on left
 		padding := width - len(samples)
-		sparkline.WriteString(strings.Repeat("░", padding))
+		sparkline.WriteString(strings.Repeat(ramp[0], padding))
 		
 		// Render each sample as a character
 		for _, value := range values {
-			sparkline.WriteString(heightToChar(value, maxValue))
+			sparkline.WriteString(heightToChar(value, maxValue, ramp))
 		}
 	} else {
 		// More samples than width - downsample
@@ -3523,41 +7639,122 @@ func generateHistoricalSparkline(samples []ActivitySample, metric string, width
 				avg = sum / count
 			}
 			
-			sparkline.WriteString(heightToChar(avg, maxValue))
+			sparkline.WriteString(heightToChar(avg, maxValue, ramp))
 		}
 	}
 	
 	return sparkline.String()
 }
 
-// heightToChar converts a value to a block character based on height
-func heightToChar(value, maxValue int) string {
-	if maxValue == 0 {
-		return "░"
-	}
-	
-	percentage := float64(value) / float64(maxValue)
-	
-	// Use block characters for 8 levels
-	if percentage == 0 {
-		return "░"
-	} else if percentage < 0.125 {
-		return "▁"
-	} else if percentage < 0.25 {
-		return "▂"
-	} else if percentage < 0.375 {
-		return "▃"
-	} else if percentage < 0.5 {
-		return "▄"
-	} else if percentage < 0.625 {
-		return "▅"
-	} else if percentage < 0.75 {
-		return "▆"
-	} else if percentage < 0.875 {
-		return "▇"
-	} else {
-		return "█"
+// sampleMetricValue returns a sparkline metric's value at samples[i] (see
+// sparklineMetricLabel for the metric names)
+func sampleMetricValue(samples []ActivitySample, i int, metric string) int {
+	sample := samples[i]
+	switch metric {
+	case "sessions":
+		return sample.SessionsCount
+	case "beacons":
+		return sample.BeaconsCount
+	case "total":
+		return sample.SessionsCount + sample.BeaconsCount
+	case "new":
+		return sample.NewCount
+	case "privileged":
+		return sample.PrivilegedCount
+	case "dead":
+		return sample.DeadCount
+	case "rate":
+		// Agents that arrived in the hour up to this sample
+		value := 0
+		for j := i; j >= 0 && sample.Timestamp.Sub(samples[j].Timestamp) < time.Hour; j-- {
+			value += samples[j].Arrivals
+		}
+		return value
+	}
+	// Per-transport series, e.g. "transport:dns"
+	if transport, ok := strings.CutPrefix(metric, "transport:"); ok {
+		return sample.TransportCounts[transport]
+	}
+	return 0
+}
+
+// defaultSparklineMetrics are the Activity Metrics rows shown when the
+// sparkline_metrics pref is unset
+var defaultSparklineMetrics = []string{"sessions", "beacons", "new", "privileged", "rate"}
+
+// sparklineMetricLabel returns the row label for a sparkline metric, or ""
+// for an unknown name. Metrics: sessions, beacons, total, new, privileged,
+// dead, rate and transport:<mtls|http|dns|tcp|other> (live agents).
+func sparklineMetricLabel(metric string) string {
+	switch metric {
+	case "sessions":
+		return "Sessions"
+	case "beacons":
+		return "Beacons"
+	case "total":
+		return "Total"
+	case "new":
+		return "New Agents"
+	case "privileged":
+		return "Privileged"
+	case "dead":
+		return "Dead"
+	case "rate":
+		return "Comp. Rate"
+	}
+	if transport, ok := strings.CutPrefix(metric, "transport:"); ok {
+		for _, known := range tracking.Transports {
+			if transport == known {
+				return strings.ToUpper(transport) + " Live"
+			}
+		}
+	}
+	return ""
+}
+
+// resolveSparklineMetrics validates configured sparkline metric names,
+// returning the known ones (in order, without duplicates) and the unknown
+// ones that were skipped
+func resolveSparklineMetrics(names []string) (metrics []string, unknown []string) {
+	seen := make(map[string]bool)
+	for _, name := range names {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if sparklineMetricLabel(key) == "" {
+			unknown = append(unknown, name)
+			continue
+		}
+		if !seen[key] {
+			seen[key] = true
+			metrics = append(metrics, key)
+		}
+	}
+	if len(metrics) == 0 {
+		metrics = defaultSparklineMetrics
+	}
+	return metrics, unknown
+}
+
+// heightToChar converts a value to a ramp character based on height
+// (ramp from config.SparklineRamp: empty cell, then 8 levels)
+func heightToChar(value, maxValue int, ramp []string) string {
+	if maxValue == 0 || value <= 0 {
+		return ramp[0]
+	}
+	
+	// 8 levels, each covering 1/8 of the range
+	level := int(float64(value)/float64(maxValue)*8) + 1
+	if level > 8 {
+		level = 8
 	}
+	return ramp[level]
+}
+
+// sparklineRamp returns the character ramp for the configured sparkline style
+func (m model) sparklineRamp() []string {
+	if m.prefs == nil {
+		return config.SparklineRamp(config.SparklineBlocks)
+	}
+	return config.SparklineRamp(m.prefs.SparklineStyle)
 }
 
 // generateTimeAxis generates time labels aligned with sparkline
@@ -3640,6 +7837,68 @@ func generateTimeAxis(samples []ActivitySample, width int, startTime time.Time)
 	return result
 }
 
+// tempoLevel combines task completions and alerts from recent refreshes
+// with the NEW agents in recent activity samples into a single busyness
+// reading, using the prefs thresholds
+func (m model) tempoLevel() (tracking.TempoLevel, int) {
+	if m.tempo == nil {
+		return tracking.TempoCalm, 0
+	}
+	totals := m.tempo.Totals()
+	events := totals.TasksCompleted + totals.Alerts
+	if m.activityTracker != nil {
+		events += m.activityTracker.RecentNewAgents(m.tempo.Window)
+	}
+	
+	prefs := m.prefs
+	if prefs == nil {
+		prefs = config.DefaultPrefs()
+	}
+	activeAt, hotAt := prefs.TempoActive, prefs.TempoHot
+	switch {
+	case hotAt > 0 && events >= hotAt:
+		return tracking.TempoHot, events
+	case activeAt > 0 && events >= activeAt:
+		return tracking.TempoActive, events
+	default:
+		return tracking.TempoCalm, events
+	}
+}
+
+// renderTempoBadge renders the tempo gauge for the header title line
+func (m model) renderTempoBadge() string {
+	if m.tempo == nil {
+		return ""
+	}
+	level, events := m.tempoLevel()
+	color := lipgloss.Color("#50fa7b")
+	switch level {
+	case tracking.TempoActive:
+		color = lipgloss.Color("#f1fa8c")
+	case tracking.TempoHot:
+		color = lipgloss.Color("#ff5555")
+	}
+	return lipgloss.NewStyle().
+		Foreground(color).
+		Background(m.theme.HeaderBg).
+		Bold(true).
+		Padding(0, 1).
+		Render(fmt.Sprintf("● %s (%d/%s)", strings.ToUpper(level.String()), events, formatAge(m.tempo.Window)))
+}
+
+// staleCriticalFactor is how many stale thresholds old the data must be
+// before the Last Update warning goes from yellow to red
+const staleCriticalFactor = 4
+
+// staleThreshold returns the data age after which the header flags it as
+// stale (0 disables the warning)
+func (m model) staleThreshold() time.Duration {
+	if m.prefs == nil {
+		return config.DefaultStaleAfter * time.Second
+	}
+	return time.Duration(m.prefs.StaleAfter) * time.Second
+}
+
 // formatDuration formats a duration in human-readable form
 func formatDuration(d time.Duration) string {
 	hours := int(d.Hours())
@@ -3651,6 +7910,37 @@ func formatDuration(d time.Duration) string {
 	return fmt.Sprintf("%dm", minutes)
 }
 
+// stateDwell formats how long an agent has been in a state since the given
+// change time ("" if unknown)
+func stateDwell(since time.Time) string {
+	if since.IsZero() {
+		return ""
+	}
+	return formatDuration(time.Since(since))
+}
+
+// formatAge formats an elapsed duration in its largest whole unit ("12s", "5m", "3h", "2d")
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", max(int(d.Seconds()), 0))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
+// formatRelativeTime formats t relative to now ("12s ago", "5m ago", "in 30s")
+func formatRelativeTime(t time.Time) string {
+	if t.After(time.Now()) {
+		return "in " + formatAge(time.Until(t))
+	}
+	return formatAge(time.Since(t)) + " ago"
+}
+
 // min returns the minimum of two integers
 func min(a, b int) int {
 	if a < b {
@@ -3766,7 +8056,15 @@ func (m *model) updateViewportContent() {
 	m.cachedContent = content
 	m.contentDirty = false
 	
-	// Set viewport content
+	// Set viewport content - skipped when a dirtying event (pulse/animation
+	// tick, etc.) produced byte-identical output, to avoid viewport churn
+	hasher := fnv.New64a()
+	hasher.Write([]byte(content))
+	hash := hasher.Sum64()
+	if hash == m.contentHash {
+		return
+	}
+	m.contentHash = hash
 	m.viewport.SetContent(content)
 }
 
@@ -3813,10 +8111,7 @@ func (m model) renderAgentBox(agent Agent) []string {
 	}
 
 	// Privilege badge
-	privBadge := ""
-	if agent.IsPrivileged && !agent.IsDead {
-		privBadge = " 💎"
-	}
+	privBadge := m.privilegeBadge(agent)
 
 	// NEW badge
 	newBadge := ""
@@ -3827,31 +8122,53 @@ func (m model) renderAgentBox(agent Agent) []string {
 			Render("✨")
 	}
 
+	// Duplicate PID warning badge
+	dupBadge := ""
+	if m.duplicatePIDs[agent.ID] {
+		dupBadge = " " + lipgloss.NewStyle().
+			Foreground(m.theme.WarningColor).
+			Bold(true).
+			Render("⚠")
+	}
+
 	// Build box content
 	// Line 1: status icon, OS icon, host type icon, username@hostname, badges
-	userInfo := fmt.Sprintf("%s %s %s %s%s%s",
+	userInfo := fmt.Sprintf("%s %s %s %s%s%s%s%s%s%s%s%s",
 		lipgloss.NewStyle().Foreground(statusColor).Render(statusIcon),
 		osIcon,
 		hostTypeIcon,
-		lipgloss.NewStyle().Foreground(usernameColor).Bold(true).Render(fmt.Sprintf("%s@%s", agent.Username, agent.Hostname)),
+		lipgloss.NewStyle().Foreground(usernameColor).Bold(true).Render(fmt.Sprintf("%s@%s", m.displayUsername(agent.Username), m.displayHostname(agent.Hostname, 0))),
+		m.watchBadge(agent),
 		privBadge,
 		newBadge,
+		dupBadge,
+		m.skewBadge(agent),
+		m.pivotCycleBadge(agent),
+		m.incompleteBadge(agent),
+		m.ackBadge(agent),
 	)
 
 	// Line 2: ID, IP, transport
-	detailsInfo := fmt.Sprintf("%s | %s | %s",
+	detailsInfo := fmt.Sprintf("%s | %s | %s%s",
 		lipgloss.NewStyle().Foreground(m.theme.TacticalMuted).Render(agent.ID[:8]),
-		lipgloss.NewStyle().Foreground(m.theme.TacticalMuted).Render(agent.RemoteAddress),
+		lipgloss.NewStyle().Foreground(m.theme.TacticalMuted).Render(displayAddress(agent.RemoteAddress)),
 		lipgloss.NewStyle().Foreground(m.theme.TacticalValue).Render(agent.Transport),
+		m.slowTransportBadge(agent),
 	)
 
 	// Combine both lines
 	content := userInfo + "\n" + detailsInfo
+	bg, tinted := m.agentBackground(agent)
+	if tinted {
+		content = applyBackground(content, bg)
+	}
 
-	// Border color
+	// Border color (watched hosts stand out in gold)
 	borderColor := m.theme.TacticalBorder
 	if agent.IsDead {
 		borderColor = m.theme.DeadColor
+	} else if m.isWatched(agent) {
+		borderColor = m.theme.WatchedColor
 	}
 
 	// Use lipgloss border style for proper continuous borders
@@ -3859,6 +8176,16 @@ func (m model) renderAgentBox(agent Agent) []string {
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(borderColor).
 		Padding(0, 1)
+	if tinted {
+		boxStyle = boxStyle.Background(bg)
+	}
+	
+	// Selected agent (click or search match) gets a thick highlighted border
+	if m.selectedAgentID == agent.ID {
+		boxStyle = boxStyle.
+			Border(lipgloss.ThickBorder()).
+			BorderForeground(m.theme.TitleColor)
+	}
 
 	// Render the box
 	boxed := boxStyle.Render(content)
@@ -4044,11 +8371,11 @@ func (m model) getAgentTypeIcon(agent Agent) string {
 	if m.iconStyle == IconStyleEmoji {
 		// Classic emoji style
 		if agent.IsDead {
-			return "💀"  // Skull for dead
+			return glyphDead  // Skull for dead
 		} else if agent.IsSession {
-			return "◆"  // Diamond for session
+			return glyphSession  // Diamond for session
 		}
-		return "◇"  // Hollow diamond for beacon
+		return glyphBeacon  // Hollow diamond for beacon
 	}
 	
 	// Nerd Font style
@@ -4060,6 +8387,165 @@ func (m model) getAgentTypeIcon(agent Agent) string {
 	return ""  // Dot-circle icon for beacon
 }
 
+// tableColumn describes one selectable Table view column
+type tableColumn struct {
+	title string
+	width int
+	value func(m model, agent Agent) string
+}
+
+// defaultTableColumns is the Table view layout used when prefs don't set one
+var defaultTableColumns = []string{"id", "type", "userhost", "os", "pid", "process", "transport", "ip", "statetime"}
+
+// tableColumns are the columns available to the Table view, keyed by the
+// name used in the table_columns pref
+var tableColumns = map[string]tableColumn{
+	"id": {"Agent ID", 10, func(m model, agent Agent) string {
+		return agent.ID
+	}},
+	"type": {"Type", 12, func(m model, agent Agent) string {
+		typeStr := "beacon"
+		if agent.IsSession {
+			typeStr = "session"
+		}
+		if agent.IsDead {
+			typeStr = "dead"
+		}
+		return fmt.Sprintf("%s %s", m.getAgentTypeIcon(agent), typeStr)
+	}},
+	"userhost": {"User@Host", 28, func(m model, agent Agent) string {
+		userHost := fmt.Sprintf("%s@%s", m.displayUsername(agent.Username), agent.Hostname)
+		if m.isWatched(agent) {
+			userHost = "⚑ " + userHost
+		}
+		return userHost
+	}},
+	"user": {"User", 20, func(m model, agent Agent) string {
+		return m.displayUsername(agent.Username)
+	}},
+	"host": {"Host", 20, func(m model, agent Agent) string {
+		if m.isWatched(agent) {
+			return "⚑ " + agent.Hostname
+		}
+		return agent.Hostname
+	}},
+	"os": {"OS", 28, func(m model, agent Agent) string {
+		// OS with architecture and icons
+		osStr := agent.OS
+		if agent.Arch != "" {
+			osStr = fmt.Sprintf("%s %s", agent.OS, agent.Arch)
+		}
+		return fmt.Sprintf("%s %s %s", m.getOSIcon(agent.OS), m.getHostTypeIcon(agent), osStr)
+	}},
+	"arch": {"Arch", 8, func(m model, agent Agent) string {
+		return agent.Arch
+	}},
+	"ip": {"IP Address", 22, func(m model, agent Agent) string {
+		return displayAddress(agent.RemoteAddress)
+	}},
+	"transport": {"Transport", 10, func(m model, agent Agent) string {
+		return agent.Transport + m.slowTransportBadge(agent)
+	}},
+	"priv": {"Priv", 6, func(m model, agent Agent) string {
+		if agent.IsPrivileged {
+			return "💎"
+		}
+		return "-"
+	}},
+	"pid": {"PID", 8, func(m model, agent Agent) string {
+		// PID, flagged when another agent on the host shares it
+		pidStr := "-"
+		if agent.PID != 0 {
+			pidStr = fmt.Sprintf("%d", agent.PID)
+		}
+		if m.duplicatePIDs[agent.ID] {
+			pidStr += " ⚠"
+		}
+		return pidStr
+	}},
+	"process": {"Process", 16, func(m model, agent Agent) string {
+		// Process name (basename of the implant path)
+		if process := extractFilename(agent.Filename); process != "" {
+			return process
+		}
+		return "-"
+	}},
+	"version": {"Version", 10, func(m model, agent Agent) string {
+		// Flagged when it differs from the fleet's most common version
+		if m.skewedVersions[agent.ID] {
+			return agent.Version + " ⇩"
+		}
+		return agent.Version
+	}},
+	"lastcheckin": {"Last Seen", 10, func(m model, agent Agent) string {
+		if agent.LastCheckin <= 0 {
+			return "-"
+		}
+		return time.Unix(agent.LastCheckin, 0).Format("15:04:05")
+	}},
+	"uptime": {"Uptime", 9, func(m model, agent Agent) string {
+		if agent.FirstSeen.IsZero() {
+			return "-"
+		}
+		return formatDuration(time.Since(agent.FirstSeen))
+	}},
+	"statetime": {"State For", 10, func(m model, agent Agent) string {
+		if dwell := stateDwell(agent.StatusSince); dwell != "" {
+			return dwell
+		}
+		return "-"
+	}},
+	"privtime": {"Admin For", 10, func(m model, agent Agent) string {
+		if dwell := stateDwell(agent.PrivilegeSince); dwell != "" && agent.IsPrivileged {
+			return dwell
+		}
+		return "-"
+	}},
+	"domain": {"Domain", 18, func(m model, agent Agent) string {
+		if domain := m.resolveAgentDomain(agent); domain != "" {
+			return domain
+		}
+		return "-"
+	}},
+	"note": {"Note", 24, func(m model, agent Agent) string {
+		if m.prefs == nil {
+			return ""
+		}
+		return m.prefs.AgentNote(agent.Hostname)
+	}},
+}
+
+// resolveTableColumns validates configured column names, returning the known
+// ones (in order, without duplicates) and the unknown ones that were skipped
+func resolveTableColumns(names []string) (columns []string, unknown []string) {
+	seen := make(map[string]bool)
+	for _, name := range names {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if _, ok := tableColumns[key]; !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		if !seen[key] {
+			seen[key] = true
+			columns = append(columns, key)
+		}
+	}
+	if len(columns) == 0 {
+		columns = defaultTableColumns
+	}
+	return columns, unknown
+}
+
+// tableColumnWidth returns a Table column's width; the Host column follows
+// the hostname_max_length pref (plus room for the watch flag)
+func (m model) tableColumnWidth(name string) int {
+	width := tableColumns[name].width
+	if name == "host" && m.prefs != nil && m.prefs.HostnameMaxLength > 0 {
+		width = m.prefs.HostnameMaxLength + 2
+	}
+	return width
+}
+
 // renderTableView renders agents in a professional table format
 func (m model) renderTableView() string {
 	var lines []string
@@ -4075,26 +8561,24 @@ func (m model) renderTableView() string {
 	deadStyle := lipgloss.NewStyle().Foreground(m.theme.DeadColor)
 	sessionStyle := lipgloss.NewStyle().Foreground(m.theme.SessionColor)
 	beaconStyle := lipgloss.NewStyle().Foreground(m.theme.BeaconColor)
+	watchedStyle := lipgloss.NewStyle().Foreground(m.theme.WatchedColor)
+	duplicateStyle := lipgloss.NewStyle().Foreground(m.theme.WarningColor).Bold(true)
+	
+	columnNames := m.tableColumns
+	if len(columnNames) == 0 {
+		columnNames = defaultTableColumns
+	}
 	
-	// Column widths (increased osWidth and typeWidth to accommodate icons)
-	idWidth := 10
-	typeWidth := 12
-	userHostWidth := 28
-	osWidth := 28
-	transportWidth := 10
-	ipWidth := 22
-	
-	// Build header with proper width handling  
-	headerRow := fmt.Sprintf("│%s│%s│%s│%s│%s│%s│",
-		headerStyle.Width(idWidth+2).Align(lipgloss.Center).Render("Agent ID"),
-		headerStyle.Width(typeWidth+2).Align(lipgloss.Center).Render("Type"),
-		headerStyle.Width(userHostWidth+2).Align(lipgloss.Center).Render("User@Host"),
-		headerStyle.Width(osWidth+2).Align(lipgloss.Center).Render("OS"),
-		headerStyle.Width(transportWidth+2).Align(lipgloss.Center).Render("Transport"),
-		headerStyle.Width(ipWidth+2).Align(lipgloss.Center).Render("IP Address"))
-	
-	// Calculate total width: sum of (width+2) for each column + 7 for the │ separators
-	totalWidth := (idWidth+2) + (typeWidth+2) + (userHostWidth+2) + (osWidth+2) + (transportWidth+2) + (ipWidth+2) + 7
+	// Build header with proper width handling
+	// Total width: sum of (width+2) for each column + one │ per separator
+	headerRow := "│"
+	totalWidth := 1
+	for _, name := range columnNames {
+		column := tableColumns[name]
+		width := m.tableColumnWidth(name)
+		headerRow += headerStyle.Width(width+2).Align(lipgloss.Center).Render(column.title) + "│"
+		totalWidth += width + 2 + 1
+	}
 	
 	// Top border
 	lines = append(lines, "┌"+strings.Repeat("─", totalWidth-2)+"┐")
@@ -4117,89 +8601,41 @@ func (m model) renderTableView() string {
 	
 	// Render rows
 	for _, agent := range flatAgents {
-		// Determine styles based on agent state
-		var idStyle, typeStyle, userHostStyle, osStyle, transportStyle, ipStyle lipgloss.Style
-		
-		if agent.IsDead {
-			idStyle = deadStyle
-			typeStyle = deadStyle
-			userHostStyle = deadStyle
-			osStyle = deadStyle
-			transportStyle = deadStyle
-			ipStyle = deadStyle
-		} else {
-			idStyle = cellStyle
-			if agent.IsSession {
-				typeStyle = sessionStyle
-			} else {
-				typeStyle = beaconStyle
+		row := "│"
+		for _, name := range columnNames {
+			column := tableColumns[name]
+			
+			// Determine style based on agent state and column
+			style := cellStyle
+			switch {
+			case agent.IsDead:
+				style = deadStyle
+			case name == "type" && agent.IsSession:
+				style = sessionStyle
+			case name == "type":
+				style = beaconStyle
+			case (name == "userhost" || name == "host") && m.isWatched(agent):
+				style = watchedStyle
+			case name == "userhost" || name == "user" || name == "priv":
+				if agent.IsPrivileged {
+					style = privilegedStyle
+				} else {
+					style = normalUserStyle
+				}
+			case name == "pid" && m.duplicatePIDs[agent.ID]:
+				style = duplicateStyle
 			}
 			
-			if agent.IsPrivileged {
-				userHostStyle = privilegedStyle
-			} else {
-				userHostStyle = normalUserStyle
+			if bg, ok := m.agentBackground(agent); ok {
+				style = style.Background(bg)
 			}
 			
-			osStyle = cellStyle
-			transportStyle = cellStyle
-			ipStyle = cellStyle
-		}
-		
-		// Truncate long fields
-		agentID := agent.ID
-		if len(agentID) > idWidth {
-			agentID = agentID[:idWidth-2] + ".."
-		}
-		
-		// Build type string with icon
-		typeIcon := m.getAgentTypeIcon(agent)
-		typeStr := "beacon"
-		if agent.IsSession {
-			typeStr = "session"
-		}
-		if agent.IsDead {
-			typeStr = "dead"
-		}
-		typeStr = fmt.Sprintf("%s %s", typeIcon, typeStr)
-		
-		userHost := fmt.Sprintf("%s@%s", agent.Username, agent.Hostname)
-		if len(userHost) > userHostWidth {
-			userHost = userHost[:userHostWidth-2] + ".."
-		}
-		
-		// OS with architecture and icons
-		osIcon := m.getOSIcon(agent.OS)
-		hostTypeIcon := m.getHostTypeIcon(agent)
-		osStr := agent.OS
-		if agent.Arch != "" {
-			osStr = fmt.Sprintf("%s %s", agent.OS, agent.Arch)
-		}
-		// Add icons: OS icon + host type icon + OS string
-		osStr = fmt.Sprintf("%s %s %s", osIcon, hostTypeIcon, osStr)
-		if len(osStr) > osWidth {
-			osStr = osStr[:osWidth-2] + ".."
-		}
-		
-		transport := agent.Transport
-		if len(transport) > transportWidth {
-			transport = transport[:transportWidth-2] + ".."
+			// Truncate long fields
+			width := m.tableColumnWidth(name)
+			value := truncateText(column.value(m, agent), width)
+			row += style.Width(width+2).Align(lipgloss.Left).Render(value) + "│"
 		}
 		
-		ipAddr := agent.RemoteAddress
-		if len(ipAddr) > ipWidth {
-			ipAddr = ipAddr[:ipWidth-2] + ".."
-		}
-		
-		// Build row - use same format as header (no spaces in format, width+2 for padding)
-		row := fmt.Sprintf("│%s│%s│%s│%s│%s│%s│",
-			idStyle.Width(idWidth+2).Align(lipgloss.Left).Render(agentID),
-			typeStyle.Width(typeWidth+2).Align(lipgloss.Left).Render(typeStr),
-			userHostStyle.Width(userHostWidth+2).Align(lipgloss.Left).Render(userHost),
-			osStyle.Width(osWidth+2).Align(lipgloss.Left).Render(osStr),
-			transportStyle.Width(transportWidth+2).Align(lipgloss.Left).Render(transport),
-			ipStyle.Width(ipWidth+2).Align(lipgloss.Left).Render(ipAddr))
-		
 		lines = append(lines, row)
 	}
 	
@@ -4244,13 +8680,27 @@ func (m model) flattenAgents(agents []Agent) []Agent {
 func (m *model) renderAgents() []string {
 	var lines []string
 
-	// Build hierarchical tree
-	tree := tree.BuildAgentTree(m.agents)
+	// Build hierarchical tree, or every agent at depth 0 in flat mode
+	var roots []Agent
+	if m.flatList {
+		roots = make([]Agent, len(m.agents))
+		copy(roots, m.agents)
+		for i := range roots {
+			roots[i].Children = nil
+		}
+	} else {
+		roots = tree.BuildAgentTree(m.agents)
+	}
+	
+	// Optionally keep dead agents out of the way of the live fleet
+	if m.prefs != nil {
+		roots = partitionDeadAgents(roots, m.prefs.DeadPlacement)
+	}
 
 	// Render tree with indentation using current view
 	currentLine := 0
-	for i, agent := range tree {
-		hasNext := i < len(tree)-1
+	for i, agent := range roots {
+		hasNext := i < len(roots)-1
 		agentLines := m.renderAgentTreeWithViewAndContext(agent, 0, m.view.Type, hasNext, !hasNext)
 		
 		// Recursively map agent lines including children
@@ -4262,6 +8712,32 @@ func (m *model) renderAgents() []string {
 	return lines
 }
 
+// partitionDeadAgents stably moves dead agents to the bottom or top of each
+// sibling list (recursively), preserving the existing order within each group
+func partitionDeadAgents(agents []Agent, placement string) []Agent {
+	if placement != config.DeadPlacementBottom && placement != config.DeadPlacementTop {
+		return agents
+	}
+	
+	live := make([]Agent, 0, len(agents))
+	var dead []Agent
+	for _, agent := range agents {
+		if len(agent.Children) > 0 {
+			agent.Children = partitionDeadAgents(agent.Children, placement)
+		}
+		if agent.IsDead {
+			dead = append(dead, agent)
+		} else {
+			live = append(live, agent)
+		}
+	}
+	
+	if placement == config.DeadPlacementTop {
+		return append(dead, live...)
+	}
+	return append(live, dead...)
+}
+
 // mapAgentLinesRecursive maps line numbers to agent IDs, accounting for tree structure
 func (m *model) mapAgentLinesRecursive(agent Agent, currentLine *int, depth int, viewType config.ViewType, hasNextSibling bool, isLastChild bool) {
 	// Determine how many lines this agent takes (not including children)
@@ -4356,10 +8832,7 @@ func (m model) renderAgentLine(agent Agent) []string {
 	}
 
 	// Privilege badge
-	privBadge := ""
-	if agent.IsPrivileged && !agent.IsDead {
-		privBadge = " 💎"
-	}
+	privBadge := m.privilegeBadge(agent)
 
 	// Dead badge (shown after hostname)
 	deadBadge := ""
@@ -4387,18 +8860,32 @@ func (m model) renderAgentLine(agent Agent) []string {
 	
 	protocolBox := protocolBoxStyle.Render(strings.ToUpper(agent.Transport))
 	
-	line1 := fmt.Sprintf("%s%s%s%s %s %s  %s%s%s %s",
+	// Agent part of each line (after the connectors/indent) - tinted separately
+	// so backgrounds don't spill over the tree connectors
+	tint := func(s string) string { return s }
+	if bg, ok := m.agentBackground(agent); ok {
+		tint = func(s string) string { return applyBackground(s, bg) }
+	}
+	
+	line1 := fmt.Sprintf("%s%s%s%s %s",
 		connectorStyle.Render("╰────────"),
 		protocolBox,
 		connectorStyle.Render("────────"),
 		connectorStyle.Render(m.getAnimatedHorizontalArrow()),
+		tint(fmt.Sprintf("%s %s  %s%s%s%s%s%s%s%s%s %s",
 		osIcon,
 		hostTypeIcon,
-		lipgloss.NewStyle().Foreground(usernameColor).Bold(true).Render(fmt.Sprintf("%s@%s", agent.Username, agent.Hostname)),
+		lipgloss.NewStyle().Foreground(usernameColor).Bold(true).Render(fmt.Sprintf("%s@%s", m.displayUsername(agent.Username), m.displayHostname(agent.Hostname, 0))),
+		m.watchBadge(agent),
+		m.skewBadge(agent),
+		m.pivotCycleBadge(agent),
+		m.incompleteBadge(agent),
+		m.ackBadge(agent),
+		m.slowTransportBadge(agent),
 		deadBadge,
 		privBadge,
 		lipgloss.NewStyle().Foreground(statusColor).Render(statusIcon),
-	)
+	)))
 
 	// Calculate indent for ID/IP lines - should align under the OS/computer icon
 	// Protocol box [ MTLS ] = 8, connector ──────── = 8, arrow ▶ = 1, space = 1
@@ -4407,18 +8894,16 @@ func (m model) renderAgentLine(agent Agent) []string {
 	idIpIndent := 27
 	
 	// Build second line - ID with connector (aligned where hostname starts)
-	line2 := fmt.Sprintf("%s└─ ID: %s (%s)%s",
-		strings.Repeat(" ", idIpIndent),
+	line2 := strings.Repeat(" ", idIpIndent) + tint(fmt.Sprintf("└─ ID: %s (%s)%s",
 		lipgloss.NewStyle().Foreground(m.theme.TacticalMuted).Render(agent.ID[:8]),
 		lipgloss.NewStyle().Foreground(statusColor).Render(typeLabel),
 		newBadge,
-	)
+	))
 	
 	// Build third line - IP with connector (aligned where hostname starts)
-	line3 := fmt.Sprintf("%s└─ IP: %s",
-		strings.Repeat(" ", idIpIndent),
-		lipgloss.NewStyle().Foreground(m.theme.TacticalMuted).Render(agent.RemoteAddress),
-	)
+	line3 := strings.Repeat(" ", idIpIndent) + tint(fmt.Sprintf("└─ IP: %s",
+		lipgloss.NewStyle().Foreground(m.theme.TacticalMuted).Render(displayAddress(agent.RemoteAddress)),
+	))
 
 	lines = append(lines, line1)
 	lines = append(lines, line2)
@@ -4442,8 +8927,10 @@ func (m model) renderAgentLine(agent Agent) []string {
 
 // Messages
 type agentsMsg struct {
-	agents []Agent
-	stats  Stats
+	agents     []Agent
+	stats      Stats
+	configPath string // Config the agents were fetched with (stale after a server switch)
+	operators  []string // Operators online (nil if the server didn't say)
 }
 
 type refreshMsg struct{}
@@ -4455,31 +8942,105 @@ type pulseTimerMsg struct{}
 type animationTickMsg struct{}
 
 type domainQueryMsg struct {
-	sessionID string
-	domain    string
+	sessionID  string
+	domain     string
+	configPath string // Server config the query was queued under
+}
+
+type dnsLookupMsg struct {
+	address    string
+	domain     string
+	configPath string // Server config the lookup was queued under
 }
 
 type errMsg struct {
-	err error
+	err        error
+	configPath string // Server config the failed fetch was for
 }
 
-// Commands
-func fetchAgentsCmd() tea.Msg {
-	// Connect to Sliver and fetch real data
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+// resizeSettledMsg fires resizeDebounce after a resize; only the one whose
+// seq matches the latest resize reflows content
+type resizeSettledMsg struct {
+	seq int
+}
 
-	agents, stats, err := client.FetchAgents(ctx)
-	if err != nil {
-		return errMsg{err: err}
+// parseTimeout parses a duration ("1500ms", "3s") or a plain number of seconds
+func parseTimeout(value string) (time.Duration, error) {
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		return time.Duration(seconds * float64(time.Second)), nil
 	}
+	return time.ParseDuration(value)
+}
+
+// refreshInterval is the nominal time between agent fetches
+const refreshInterval = 5 * time.Second
+
+// refreshDelay returns refreshInterval randomly stretched or shrunk by up
+// to the RefreshJitter fraction, so several operators polling one server
+// drift apart instead of hitting it in lockstep
+func (m model) refreshDelay() time.Duration {
+	jitter := config.DefaultRefreshJitter
+	if m.prefs != nil {
+		jitter = m.prefs.RefreshJitter
+	}
+	if jitter <= 0 {
+		return refreshInterval
+	}
+	if jitter > config.MaxRefreshJitter {
+		jitter = config.MaxRefreshJitter
+	}
+	offset := (rand.Float64()*2 - 1) * jitter // [-jitter, +jitter)
+	return time.Duration(float64(refreshInterval) * (1 + offset))
+}
+
+// resizeDebounce is how long the terminal size must hold still before the
+// content is re-rendered for it
+const resizeDebounce = 150 * time.Millisecond
+
+// fetchCmd fetches agents from the server, or from the synthetic fleet in
+// demo mode
+func (m model) fetchCmd() tea.Cmd {
+	if m.demoFleet != nil {
+		return fetchDemoAgentsCmd(m.demoFleet, m.clientOpts, m.tracker)
+	}
+	return fetchAgentsCmd(m.clientOpts, m.tracker)
+}
+
+// fetchDemoAgentsCmd advances the synthetic fleet and feeds it through the
+// same conversion and tracking as a live fetch
+func fetchDemoAgentsCmd(fleet *demo.Fleet, opts client.Options, tracker *tracking.Tracker) tea.Cmd {
+	return func() tea.Msg {
+		sessions, beacons := fleet.Next()
+		agents, stats := client.ConvertToAgents(sessions, beacons, nil, opts)
+		agents = tracker.TrackAgentChanges(agents)
+		
+		return agentsMsg{
+			agents:     agents,
+			stats:      stats,
+			configPath: opts.ConfigPath,
+		}
+	}
+}
+
+// Commands
+func fetchAgentsCmd(opts client.Options, tracker *tracking.Tracker) tea.Cmd {
+	return func() tea.Msg {
+		// Connect to Sliver and fetch real data; FetchAgents bounds the
+		// connect and each RPC by opts.Timeout() on their own
+		agents, stats, operators, err := client.FetchAgents(context.Background(), opts)
+		if err != nil {
+			return errMsg{err: err, configPath: opts.ConfigPath}
+		}
 
-	// Track agent changes (NEW badges, lost agents)
-	agents = tracking.TrackAgentChanges(agents)
+		// Track agent changes (NEW badges, lost agents)
+		agents = tracker.TrackAgentChanges(agents)
 
-	return agentsMsg{
-		agents: agents,
-		stats:  stats,
+		return agentsMsg{
+			agents:     agents,
+			stats:      stats,
+			configPath: opts.ConfigPath,
+			operators:  operators,
+		}
 	}
 }
 
@@ -4501,26 +9062,59 @@ func animationTickCmd() tea.Msg {
 	return animationTickMsg{}
 }
 
+// maxDomainQueries caps concurrent background domain queries. Each opens its
+// own gRPC connection, so firing one per session at once on a large fleet
+// floods the server.
+const maxDomainQueries = 4
+
+// domainQuerySlots is a counting semaphore for queryDomainCmd; queries past
+// the cap wait for a slot (timeouts start once a query runs)
+var domainQuerySlots = make(chan struct{}, maxDomainQueries)
+
+// maxDNSLookups caps concurrent background reverse DNS lookups, so a large
+// fleet without PTR records doesn't start hundreds of resolver calls at once
+const maxDNSLookups = 8
+
+// dnsLookupSlots is a counting semaphore for dnsLookupCmd
+var dnsLookupSlots = make(chan struct{}, maxDNSLookups)
+
+// dnsLookupCmd reverse-resolves an agent address in the background
+func dnsLookupCmd(address, configPath string) tea.Cmd {
+	return func() tea.Msg {
+		dnsLookupSlots <- struct{}{}
+		defer func() { <-dnsLookupSlots }()
+		
+		return dnsLookupMsg{
+			address:    address,
+			domain:     config.ResolveDomainFromIP(address),
+			configPath: configPath,
+		}
+	}
+}
+
 // queryDomainCmd queries domain from a session in the background
-func queryDomainCmd(sessionID string) tea.Cmd {
+func queryDomainCmd(sessionID string, opts client.Options) tea.Cmd {
 	return func() tea.Msg {
+		domainQuerySlots <- struct{}{}
+		defer func() { <-domainQuerySlots }()
+		
 		// Connect to Sliver
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), opts.Timeout())
 		defer cancel()
 		
-		configPath, err := client.FindConfigFile()
+		configPath, err := client.ResolveConfigPath(opts.ConfigPath)
 		if err != nil {
-			return domainQueryMsg{sessionID: sessionID, domain: ""}
+			return domainQueryMsg{sessionID: sessionID, configPath: opts.ConfigPath}
 		}
 		
 		config, err := client.LoadConfig(configPath)
 		if err != nil {
-			return domainQueryMsg{sessionID: sessionID, domain: ""}
+			return domainQueryMsg{sessionID: sessionID, configPath: opts.ConfigPath}
 		}
 		
 		sliverClient := client.NewSliverClient(config)
 		if err := sliverClient.Connect(ctx); err != nil {
-			return domainQueryMsg{sessionID: sessionID, domain: ""}
+			return domainQueryMsg{sessionID: sessionID, configPath: opts.ConfigPath}
 		}
 		defer sliverClient.Close()
 		
@@ -4531,23 +9125,115 @@ func queryDomainCmd(sessionID string) tea.Cmd {
 		domain := sliverClient.QueryDomainFromSession(queryCtx, sessionID)
 		
 		return domainQueryMsg{
-			sessionID: sessionID,
-			domain:    domain,
+			sessionID:  sessionID,
+			domain:     domain,
+			configPath: opts.ConfigPath,
 		}
 	}
 }
 
 func main() {
+	// Command-line flags
+	configPath := flag.String("config", "", "Path to a Sliver operator config (.cfg); skips auto-discovery in ~/.sliver-client/configs (or $SLIVER_CLIENT_DIR)")
+	demoMode := flag.Bool("demo", os.Getenv("SLIVER_TUI_DEMO") == "1", "Show an evolving synthetic fleet instead of connecting to a server (also SLIVER_TUI_DEMO=1)")
+	startViewName := flag.String("view", "", "View to start in: box, table, dashboard or network_map")
+	startPage := flag.Int("page", 0, "Dashboard page to start on (1 = OVERVIEW ...); implies -view dashboard")
+	flag.Parse()
+	
+	// Startup view/page from flags
+	startView := 0
+	if *startViewName != "" {
+		index, ok := config.ViewIndexByName(*startViewName)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Error: unknown view %q (box, table, dashboard, network_map)\n", *startViewName)
+			os.Exit(1)
+		}
+		startView = index
+	}
+	if *startPage != 0 {
+		if *startPage < 1 || *startPage > len(dashboardPages) {
+			fmt.Fprintf(os.Stderr, "Error: dashboard page %d out of range (1-%d)\n", *startPage, len(dashboardPages))
+			os.Exit(1)
+		}
+		if *startViewName == "" {
+			startView, _ = config.ViewIndexByName("dashboard")
+		} else if config.GetView(startView).Type != config.ViewTypeDashboard {
+			fmt.Fprintf(os.Stderr, "Error: -page needs the dashboard view, not %q\n", *startViewName)
+			os.Exit(1)
+		}
+	}
+	
+	if *configPath != "" && !*demoMode {
+		if _, err := client.ResolveConfigPath(*configPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	
+	// Load persisted preferences (defaults if missing or unreadable)
+	prefs, err := config.LoadPrefs()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v (using defaults)\n", err)
+	}
+	
 	// Create spinner
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 	
-	// Initialize with default theme (index 3 = Matrix theme)
-	defaultTheme := config.GetTheme(3)
+	// Initialize with the last theme used (default index 3 = Matrix theme)
+	themeIndex := 3
+	if recent := prefs.ValidRecentThemes(); len(recent) > 0 {
+		themeIndex = recent[0]
+	}
+	defaultTheme := config.GetTheme(themeIndex)
 	s.Style = lipgloss.NewStyle().Foreground(defaultTheme.TitleColor)
 	
-	// Initialize with default view (index 0)
-	defaultView := config.GetView(0)
+	// Initialize with the startup view (index 0 unless -view/-page)
+	defaultView := config.GetView(startView)
+	
+	// Table view columns from prefs (unknown names are skipped)
+	tableColumns, unknownColumns := resolveTableColumns(prefs.TableColumns)
+	if len(unknownColumns) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring unknown table columns: %s\n", strings.Join(unknownColumns, ", "))
+	}
+	
+	// Activity Metrics sparkline rows from prefs (unknown names are skipped)
+	sparklineMetrics, unknownMetrics := resolveSparklineMetrics(prefs.SparklineMetrics)
+	if len(unknownMetrics) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring unknown sparkline metrics: %s\n", strings.Join(unknownMetrics, ", "))
+	}
+	
+	// Per-transport dead tolerances from prefs override the defaults
+	clientOpts := client.DefaultOptions()
+	clientOpts.ConfigPath = *configPath
+	for transport, tolerance := range prefs.TransportTolerance {
+		clientOpts.DeadTolerance[strings.ToLower(transport)] = tolerance
+	}
+	for username, privileged := range prefs.PrivilegeOverrides {
+		clientOpts.PrivilegeOverrides[strings.ToLower(strings.TrimSpace(username))] = privileged
+	}
+	
+	// Connect timeout: env (e.g. "3s" or "3") over prefs (seconds)
+	clientOpts.ConnectTimeout = time.Duration(prefs.ConnectTimeout * float64(time.Second))
+	if value := os.Getenv("SLIVER_TUI_CONNECT_TIMEOUT"); value != "" {
+		if timeout, err := parseTimeout(value); err == nil {
+			clientOpts.ConnectTimeout = timeout
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: ignoring SLIVER_TUI_CONNECT_TIMEOUT=%q: %v\n", value, err)
+		}
+	}
+	if clientOpts.ConnectTimeout != 0 && clientOpts.Timeout() != clientOpts.ConnectTimeout {
+		fmt.Fprintf(os.Stderr, "Warning: connect timeout %s out of range (%s-%s), using %s\n",
+			clientOpts.ConnectTimeout, client.MinConnectTimeout, client.MaxConnectTimeout, client.DefaultConnectTimeout)
+	}
+
+	// Alert TTL overrides from prefs (seconds)
+	alertTTLs := alerts.DefaultTTLConfig()
+	for name, seconds := range prefs.AlertTTLs {
+		if seconds <= 0 || !alertTTLs.Set(name, time.Duration(seconds*float64(time.Second))) {
+			fmt.Fprintf(os.Stderr, "Warning: ignoring alert TTL %q=%v\n", name, seconds)
+		}
+	}
 
 	// Initialize model with default terminal size as fallback
 	m := model{
@@ -4556,24 +9242,41 @@ func main() {
 		loading:         true,
 		termWidth:       180, // Default fallback width
 		termHeight:      40,  // Default fallback height
-		themeIndex:      3,   // Start with Matrix theme
+		themeIndex:      themeIndex,
 		theme:           defaultTheme,
-		viewIndex:       0,   // Start with default view
+		viewIndex:       startView,
 		view:            defaultView,
+		dashboardPage:   max(*startPage-1, 0),
 		activityTracker: NewActivityTracker(), // Initialize activity tracker
-		expandedSubnets: make(map[string]bool), // Initialize expanded subnets map
-		alertManager:    alerts.NewAlertManager(5), // Max 5 visible alerts
+		expandedSubnets: prefs.ExpandedSubnetMap(), // Restore expanded subnets from prefs
+		alertManager:    alerts.NewAlertManagerWithTTLs(5, alertTTLs), // Max 5 visible alerts
 		previousAgents:  make(map[string]Agent), // Initialize agent tracking map
-		dnsCache:        make(map[string]string), // Initialize DNS cache
-		domainCache:     make(map[string]string), // Initialize domain cache (sessionID -> domain)
+		prevPrivileged:  -1,
+		dnsCache:        newDNSCache(),
+		domainCache:     newDomainCache(),
+		domainQueries:   make(map[string]bool),
+		dnsLookups:      make(map[string]bool),
 		agentLineMap:    make(map[int]string),   // Initialize agent line map for mouse clicks
 		alertLineMap:    make(map[int]string),   // Initialize alert line map for mouse clicks
 		mouseEnabled:    true,                    // Enable mouse support
 		expandedProcessPaths: make(map[string]bool), // Initialize process path expansion map
+		ackedAgents:     make(map[string]time.Time), // Initialize acknowledged agents map
+		hiddenAgents:    make(map[string]bool),
+		opsLog:          tracking.NewOpsLog(500),    // Keep the last 500 task events
+		tempo:           tracking.NewTempoTracker(), // Rolling window for the tempo gauge
+		tracker:         tracking.NewTracker(),      // Initialize NEW/lost agent tracking
+		prefs:           prefs,
+		clientOpts:      clientOpts,
+		tableColumns:    tableColumns,
+		sparklineMetrics: sparklineMetrics,
+	}
+	if *demoMode {
+		m.demoFleet = demo.NewFleet()
 	}
+	m.alertManager.SetCoalesce(prefs.AlertCoalesce)
 
 	// Create and run program with alt screen
-	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion(), tea.WithOutput(termOutput))
 
 	if _, err := p.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -0,0 +1,790 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bishopfox/sliver/protobuf/clientpb"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/musyoka101/sliver-graphs/internal/alerts"
+	"github.com/musyoka101/sliver-graphs/internal/client"
+	"github.com/musyoka101/sliver-graphs/internal/config"
+	"github.com/musyoka101/sliver-graphs/internal/models"
+	"github.com/musyoka101/sliver-graphs/internal/tracking"
+)
+
+// newTestModel builds a model the way main does, minus the terminal and
+// the server connection
+func newTestModel() model {
+	prefs := config.DefaultPrefs()
+	tableColumns, _ := resolveTableColumns(prefs.TableColumns)
+	sparklineMetrics, _ := resolveSparklineMetrics(prefs.SparklineMetrics)
+	return model{
+		agents:               []Agent{},
+		termWidth:            180,
+		termHeight:           40,
+		themeIndex:           3,
+		theme:                config.GetTheme(3),
+		view:                 config.GetView(0),
+		activityTracker:      NewActivityTracker(),
+		expandedSubnets:      make(map[string]bool),
+		alertManager:         alerts.NewAlertManager(5),
+		previousAgents:       make(map[string]Agent),
+		prevPrivileged:       -1,
+		dnsCache:             newDNSCache(),
+		domainCache:          newDomainCache(),
+		domainQueries:        make(map[string]bool),
+		dnsLookups:           make(map[string]bool),
+		agentLineMap:         make(map[int]string),
+		alertLineMap:         make(map[int]string),
+		expandedProcessPaths: make(map[string]bool),
+		ackedAgents:          make(map[string]time.Time),
+		hiddenAgents:         make(map[string]bool),
+		opsLog:               tracking.NewOpsLog(500),
+		tempo:                tracking.NewTempoTracker(),
+		tracker:              tracking.NewTracker(),
+		prefs:                prefs,
+		clientOpts:           client.DefaultOptions(),
+		tableColumns:         tableColumns,
+		sparklineMetrics:     sparklineMetrics,
+	}
+}
+
+func TestQueueDNSLookupsAfterExpiry(t *testing.T) {
+	m := newTestModel()
+	m.dnsCache = config.NewTTLCache(16, 30*time.Millisecond, 10*time.Millisecond)
+	agents := []Agent{
+		{ID: "b1", Hostname: "WS01", RemoteAddress: "10.0.0.5:443"},
+		{ID: "b2", Hostname: "WS02", RemoteAddress: "10.0.0.6:443"},
+		{ID: "b3", Hostname: "db.corp.local", RemoteAddress: "10.0.0.7:443"}, // Domain from FQDN
+	}
+
+	if cmds := m.queueDNSLookups(agents); len(cmds) != 2 {
+		t.Fatalf("first refresh queued %d lookups, want 2", len(cmds))
+	}
+	if cmds := m.queueDNSLookups(agents); len(cmds) != 0 {
+		t.Fatalf("lookups in flight were queued again (%d)", len(cmds))
+	}
+
+	// Results arrive: one found, one failed
+	m.dnsCache.Set("10.0.0.5:443", "corp.local")
+	m.dnsCache.Set("10.0.0.6:443", "")
+	delete(m.dnsLookups, "10.0.0.5:443")
+	delete(m.dnsLookups, "10.0.0.6:443")
+	if cmds := m.queueDNSLookups(agents); len(cmds) != 0 {
+		t.Fatalf("cached addresses were looked up again (%d)", len(cmds))
+	}
+	if domain := m.resolveAgentDomain(agents[0]); domain != "corp.local" {
+		t.Errorf("resolveAgentDomain = %q, want corp.local", domain)
+	}
+
+	// The failed lookup expires first and is retried alone
+	time.Sleep(15 * time.Millisecond)
+	if cmds := m.queueDNSLookups(agents); len(cmds) != 1 || !m.dnsLookups["10.0.0.6:443"] {
+		t.Fatalf("after miss TTL queued %d lookups, want the failed address only", len(cmds))
+	}
+
+	// Then the found value expires and is re-resolved
+	time.Sleep(25 * time.Millisecond)
+	if cmds := m.queueDNSLookups(agents); len(cmds) != 1 || !m.dnsLookups["10.0.0.5:443"] {
+		t.Fatalf("after TTL queued %d lookups, want the expired address", len(cmds))
+	}
+}
+
+func TestToggleAllSubnets(t *testing.T) {
+	subnets := []string{"10.0.1.0/24", "10.0.2.0/24", "10.0.3.0/24"}
+	tests := []struct {
+		name     string
+		expanded map[string]bool
+		want     bool
+	}{
+		{"all collapsed expands all", map[string]bool{"10.0.1.0/24": false, "10.0.2.0/24": false, "10.0.3.0/24": false}, true},
+		{"mixed expands all", map[string]bool{"10.0.1.0/24": true, "10.0.2.0/24": false, "10.0.3.0/24": true}, true},
+		{"all expanded collapses all", map[string]bool{"10.0.1.0/24": true, "10.0.2.0/24": true, "10.0.3.0/24": true}, false},
+		{"new subnet missing from map expands all", map[string]bool{"10.0.1.0/24": true, "10.0.2.0/24": true}, true},
+		{"empty map expands all", map[string]bool{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			toggleAllSubnets(tt.expanded, subnets)
+			for _, subnet := range subnets {
+				if tt.expanded[subnet] != tt.want {
+					t.Errorf("%s expanded = %v, want %v", subnet, tt.expanded[subnet], tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestToggleAllSubnetsIncludesNewSubnet(t *testing.T) {
+	m := newTestModel()
+	m.agents = []Agent{
+		{ID: "a", RemoteAddress: "10.0.1.5:443"},
+		{ID: "b", RemoteAddress: "10.0.2.5:443"},
+	}
+	m.updateSubnetOrder()
+	toggleAllSubnets(m.expandedSubnets, m.subnetOrder)
+
+	// A subnet appears after the last render, then 'e' is pressed again:
+	// not everything is expanded any more, so everything expands
+	m.agents = append(m.agents, Agent{ID: "c", RemoteAddress: "10.0.3.5:443"})
+	m.updateSubnetOrder()
+	toggleAllSubnets(m.expandedSubnets, m.subnetOrder)
+	for _, subnet := range m.subnetOrder {
+		if !m.expandedSubnets[subnet] {
+			t.Errorf("%s collapsed after expand all", subnet)
+		}
+	}
+
+	toggleAllSubnets(m.expandedSubnets, m.subnetOrder)
+	for _, subnet := range m.subnetOrder {
+		if m.expandedSubnets[subnet] {
+			t.Errorf("%s expanded after collapse all", subnet)
+		}
+	}
+}
+
+func TestTruncateText(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    string
+		width int
+		want  string
+	}{
+		{"ascii fits", "WS01", 10, "WS01"},
+		{"ascii cut", "FILESERVER-PRIMARY", 8, "FILESER…"},
+		{"latin accents", "école-dc01", 3, "éc…"},
+		{"CJK fits exactly", "東京サーバー01", 14, "東京サーバー01"},
+		{"CJK cut on a cell boundary", "東京サーバー01", 7, "東京サ…"},
+		{"CJK cut mid wide rune", "東京サーバー01", 8, "東京サ…"}, // ー would straddle the edge
+		{"ZWJ emoji kept whole", "👨\u200d👩\u200d👧 family-pc", 3, "👨\u200d👩\u200d👧…"},
+		{"ZWJ emoji not split", "👨\u200d👩\u200d👧 family-pc", 2, "…"},
+		{"flag dropped whole", "ws-🇺🇸-01", 5, "ws-…"},
+		{"flag kept whole", "ws-🇺🇸-01", 6, "ws-🇺🇸…"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncateText(tt.in, tt.width)
+			if got != tt.want {
+				t.Errorf("truncateText(%q, %d) = %q, want %q", tt.in, tt.width, got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("truncateText(%q, %d) = %q is not valid UTF-8", tt.in, tt.width, got)
+			}
+			if width := ansi.StringWidth(got); width > tt.width {
+				t.Errorf("truncateText(%q, %d) is %d cells wide", tt.in, tt.width, width)
+			}
+		})
+	}
+}
+
+// refreshAgents runs one refresh's change detection, as agentsMsg does
+func refreshAgents(m *model, agents ...Agent) {
+	m.detectAgentChanges(agents, Stats{})
+}
+
+// alertCategories returns the categories of the model's live alerts
+func alertCategories(m *model) []alerts.AlertCategory {
+	var categories []alerts.AlertCategory
+	for _, alert := range m.alertManager.GetAlerts() {
+		categories = append(categories, alert.Category)
+	}
+	return categories
+}
+
+func TestBeaconPromotionRaisesOneAlert(t *testing.T) {
+	m := newTestModel()
+	beacon := Agent{ID: "beacon-1", Hostname: "WS01", PID: 4242, RemoteAddress: "10.0.0.5:51234"}
+	refreshAgents(&m, beacon)
+	m.alertManager.ClearAll() // Drop the first blood alert
+
+	// Sliver registers the promoted session under a new ID
+	session := Agent{ID: "session-1", Hostname: "WS01", PID: 4242, RemoteAddress: "10.0.0.5:51301", IsSession: true}
+	refreshAgents(&m, session)
+
+	got := alertCategories(&m)
+	if len(got) != 1 || got[0] != alerts.CategorySessionOpened {
+		t.Fatalf("alerts after promotion = %v, want one %v", got, alerts.CategorySessionOpened)
+	}
+	if len(m.pendingLost) != 0 {
+		t.Errorf("promoted beacon held as lost: %v", m.pendingLost)
+	}
+
+	// Nothing more on the next refresh either
+	refreshAgents(&m, session)
+	if got := alertCategories(&m); len(got) != 1 {
+		t.Errorf("alerts on the next refresh = %v, want only the upgrade", got)
+	}
+}
+
+func TestExtractSubnet(t *testing.T) {
+	tests := []struct {
+		address string
+		want    string
+	}{
+		{"10.0.0.5:443", "10.0.0.0/24"},
+		{"192.168.1.20", "192.168.1.0/24"},
+		{"", unknownSubnet},
+		{"pivot-host:8080", unknownSubnet},
+	}
+	for _, tt := range tests {
+		if got := extractSubnet(tt.address); got != tt.want {
+			t.Errorf("extractSubnet(%q) = %q, want %q", tt.address, got, tt.want)
+		}
+	}
+}
+
+func TestNoAddressAgentInUnknownBucket(t *testing.T) {
+	m := newTestModel()
+	noAddress := Agent{ID: "a", Hostname: "NOADDR", IsSession: true, OS: "windows", Transport: "mtls"}
+	m.agents = []Agent{
+		noAddress,
+		{ID: "b", Hostname: "WS02", RemoteAddress: "10.0.0.5:443", OS: "linux", Transport: "http"},
+	}
+
+	groups, subnets := groupAgentsBySubnet(m.agents)
+	if group := groups[unknownSubnet]; group == nil || len(group.Agents) != 1 || group.Agents[0].ID != "a" {
+		t.Fatalf("groupAgentsBySubnet Unknown group = %+v, want the no-address agent", group)
+	}
+	if len(subnets) != 2 {
+		t.Errorf("groupAgentsBySubnet subnets = %v, want 2", subnets)
+	}
+
+	m.updateSubnetOrder()
+	found := false
+	for _, subnet := range m.subnetOrder {
+		found = found || subnet == unknownSubnet
+	}
+	if !found {
+		t.Errorf("subnetOrder %v has no %s entry (no number shortcut)", m.subnetOrder, unknownSubnet)
+	}
+
+	// Every subnet panel lists the bucket instead of dropping the agent
+	panels := map[string]string{
+		"tactical":    m.renderTacticalPanel(),
+		"topology":    m.renderNetworkTopologyPanel(),
+		"network map": m.renderNetworkMapView(),
+		"histogram":   m.renderSubnetHistogramPanel(),
+	}
+	for name, panel := range panels {
+		if !strings.Contains(ansi.Strip(panel), unknownSubnet) {
+			t.Errorf("%s panel has no %s subnet", name, unknownSubnet)
+		}
+	}
+
+	// Address cells show the placeholder, never a blank
+	if got := tableColumns["ip"].value(m, noAddress); got != noAddressPlaceholder {
+		t.Errorf("table ip cell = %q, want %q", got, noAddressPlaceholder)
+	}
+}
+
+func TestSubnetHistogramNumbersAndRamp(t *testing.T) {
+	m := newTestModel()
+	m.prefs.SparklineStyle = config.SparklineASCII
+	m.agents = []Agent{
+		{ID: "a", Hostname: "WS01", RemoteAddress: "10.0.0.5:443"},
+		{ID: "b", Hostname: "WS02", RemoteAddress: "10.0.1.5:443"},
+		{ID: "c", Hostname: "WS03", RemoteAddress: "10.0.1.6:443"},
+	}
+	m.updateSubnetOrder()
+
+	panel := ansi.Strip(m.renderSubnetHistogramPanel())
+	// The busiest subnet's bar comes first but keeps its topology number
+	for _, want := range []string{"2   10.0.1.0/24", "1   10.0.0.0/24"} {
+		if !strings.Contains(panel, want) {
+			t.Errorf("histogram has no %q row:\n%s", want, panel)
+		}
+	}
+	if strings.ContainsAny(panel, "▁▂▃▄▅▆▇█") {
+		t.Errorf("histogram uses block characters with the ascii sparkline style:\n%s", panel)
+	}
+}
+
+func TestNoteColumn(t *testing.T) {
+	m := newTestModel()
+	m.prefs.AgentNotes = map[string]string{"DC01": "primary DC", " ws02 ": "jump box"}
+	columns, unknown := resolveTableColumns([]string{"host", "note"})
+	if len(unknown) != 0 || len(columns) != 2 {
+		t.Fatalf("resolveTableColumns = %v (unknown %v), want host and note", columns, unknown)
+	}
+
+	tests := []struct {
+		hostname string
+		want     string
+	}{
+		{"DC01", "primary DC"},
+		{"dc01", "primary DC"}, // Hostnames match case-insensitively
+		{"WS02", "jump box"},   // Keys are trimmed
+		{"WS03", ""},
+	}
+	for _, tt := range tests {
+		if got := tableColumns["note"].value(m, Agent{Hostname: tt.hostname}); got != tt.want {
+			t.Errorf("note for %s = %q, want %q", tt.hostname, got, tt.want)
+		}
+	}
+}
+
+func TestCycleViewsWithoutPrefs(t *testing.T) {
+	m := newTestModel()
+	m.prefs = nil
+	m = update(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	if m.viewIndex != 1 {
+		t.Errorf("viewIndex after v = %d, want 1", m.viewIndex)
+	}
+}
+
+func TestSlowTransportBadgeInTreeAndTable(t *testing.T) {
+	m := newTestModel()
+	dns := Agent{ID: "8f14e45f-ceea-467f-a7a0-6c1e0d7f6b21", Hostname: "WS01", Username: "alice",
+		RemoteAddress: "10.0.0.5:53", OS: "windows", Transport: "dns"}
+	mtls := dns
+	mtls.Transport = "mtls"
+	dead := dns
+	dead.IsDead = true
+
+	tests := []struct {
+		agent Agent
+		want  bool
+	}{
+		{dns, true},
+		{mtls, false},
+		{dead, false},
+	}
+	for _, tt := range tests {
+		line := ansi.Strip(strings.Join(m.renderAgentLine(tt.agent), "\n"))
+		if got := strings.Contains(line, "🐢"); got != tt.want {
+			t.Errorf("%s (dead %v) tree line has 🐢 = %v, want %v", tt.agent.Transport, tt.agent.IsDead, got, tt.want)
+		}
+		cell := tableColumns["transport"].value(m, tt.agent)
+		if got := strings.Contains(cell, "🐢"); got != tt.want {
+			t.Errorf("%s (dead %v) table cell %q has 🐢 = %v, want %v", tt.agent.Transport, tt.agent.IsDead, cell, got, tt.want)
+		}
+	}
+}
+
+func TestPartialSessionRendersSanely(t *testing.T) {
+	// A session mid-handshake: no OS, transport or address yet
+	sessions := []*clientpb.Session{{ID: "8f14e45f-ceea-467f-a7a0-6c1e0d7f6b21", Hostname: "HALF", Username: "svc"}}
+	agents, _ := client.ConvertToAgents(sessions, nil, nil, client.DefaultOptions())
+	if len(agents) != 1 {
+		t.Fatalf("ConvertToAgents returned %d agents, want 1", len(agents))
+	}
+	agent := agents[0]
+	if !agent.Incomplete || agent.OS != "unknown" || agent.Transport != "unknown" {
+		t.Fatalf("partial session = {Incomplete:%v OS:%q Transport:%q}, want incomplete with unknown OS/transport",
+			agent.Incomplete, agent.OS, agent.Transport)
+	}
+
+	m := newTestModel()
+	m.agents = agents
+	if m.getOSIcon(agent.OS) == "" {
+		t.Error("no OS icon for an unknown OS")
+	}
+
+	line := ansi.Strip(strings.Join(m.renderAgentLine(agent), "\n"))
+	for _, want := range []string{"UNKNOWN", "HALF", noAddressPlaceholder, "◌"} {
+		if !strings.Contains(line, want) {
+			t.Errorf("agent line missing %q:\n%s", want, line)
+		}
+	}
+
+	for _, name := range []string{"os", "transport", "ip", "type"} {
+		if cell := strings.TrimSpace(tableColumns[name].value(m, agent)); cell == "" {
+			t.Errorf("table %s cell is blank", name)
+		}
+	}
+
+	// Bucketed as unknown in the tactical panel, not dropped or blank
+	panel := ansi.Strip(m.renderTacticalPanel())
+	for _, want := range []string{"Unknown: 1", "unknown: 1", unknownSubnet + " (1 agent)"} {
+		if !strings.Contains(panel, want) {
+			t.Errorf("tactical panel missing %q", want)
+		}
+	}
+}
+
+func TestResurrectionRaisesOneAlert(t *testing.T) {
+	m := newTestModel()
+	dead := Agent{ID: "beacon-1", Hostname: "WS01", RemoteAddress: "10.0.0.5:443", IsDead: true}
+	refreshAgents(&m, dead)
+	refreshAgents(&m, dead)
+	m.alertManager.ClearAll()
+
+	alive := dead
+	alive.IsDead = false
+	refreshAgents(&m, alive)
+	got := alertCategories(&m)
+	if len(got) != 1 || got[0] != alerts.CategoryBeaconResurrected {
+		t.Fatalf("alerts on dead→alive = %v, want one %v", got, alerts.CategoryBeaconResurrected)
+	}
+
+	// Only the transition alerts; staying alive raises nothing more
+	m.alertManager.ClearAll()
+	refreshAgents(&m, alive)
+	if got := alertCategories(&m); len(got) != 0 {
+		t.Errorf("alerts while still alive = %v, want none", got)
+	}
+}
+
+// update feeds msg to the model's Update, as the Bubble Tea runtime does
+func update(t testing.TB, m model, msg tea.Msg) model {
+	t.Helper()
+	next, _ := m.Update(msg)
+	return next.(model)
+}
+
+// testFleet returns n live agents spread over /24s of 200 hosts each
+func testFleet(n int) []Agent {
+	agents := make([]Agent, n)
+	for i := range agents {
+		agents[i] = Agent{
+			ID:            fmt.Sprintf("%08d-0000-4000-8000-000000000000", i),
+			Hostname:      fmt.Sprintf("WS%03d", i),
+			RemoteAddress: fmt.Sprintf("10.0.%d.%d:443", i/200, i%200),
+			OS:            "windows",
+			Transport:     "mtls",
+			IsSession:     i%2 == 0,
+		}
+	}
+	return agents
+}
+
+// pulseSetContents drives ticks pulse ticks and returns how many reached
+// viewport.SetContent (the content hash only changes when it is called)
+func pulseSetContents(t testing.TB, m model, ticks int) (model, int) {
+	sets := 0
+	for i := 0; i < ticks; i++ {
+		before := m.contentHash
+		m = update(t, m, pulseTimerMsg{})
+		if m.contentHash != before {
+			sets++
+		}
+	}
+	return m, sets
+}
+
+func TestActivitySamplesOnlyOnTimerAndChanges(t *testing.T) {
+	m := newTestModel()
+	m = update(t, m, tea.WindowSizeMsg{Width: 180, Height: 40})
+	samples := func() int { return len(m.activityTracker.GetSamples()) }
+	refresh := func(n int) {
+		m = update(t, m, agentsMsg{agents: testFleet(n), stats: Stats{Sessions: n}})
+	}
+
+	// The first fetch seeds the history; unchanged refreshes add nothing
+	for i := 0; i < 5; i++ {
+		refresh(3)
+	}
+	if got := samples(); got != 1 {
+		t.Fatalf("%d samples after 5 unchanged refreshes, want 1", got)
+	}
+
+	// A new agent inside the minimum gap doesn't sample
+	refresh(4)
+	if got := samples(); got != 1 {
+		t.Fatalf("%d samples after a change within %s, want 1", got, minForcedSampleGap)
+	}
+
+	// Past the gap, the next change does, once
+	m.lastSampleAt = m.lastSampleAt.Add(-minForcedSampleGap)
+	refresh(4)
+	if got := samples(); got != 1 {
+		t.Fatalf("%d samples after an unchanged refresh, want 1", got)
+	}
+	refresh(5)
+	refresh(5)
+	refresh(5)
+	if got := samples(); got != 2 {
+		t.Fatalf("%d samples after a change past the gap, want 2", got)
+	}
+
+	// The timer keeps the steady cadence
+	m = update(t, m, activitySampleMsg{})
+	if got := samples(); got != 3 {
+		t.Errorf("%d samples after a timer tick, want 3", got)
+	}
+}
+
+func TestUnchangedContentSkipsSetContent(t *testing.T) {
+	m := newTestModel()
+	m = update(t, m, tea.WindowSizeMsg{Width: 180, Height: 40})
+	m = update(t, m, agentsMsg{agents: testFleet(20), stats: Stats{Sessions: 10, Beacons: 10}})
+
+	m, sets := pulseSetContents(t, m, 10)
+	if sets != 0 {
+		t.Errorf("%d of 10 pulse ticks on an unchanged fleet called SetContent, want 0", sets)
+	}
+
+	// A real change still reaches the viewport
+	before := m.contentHash
+	m = update(t, m, agentsMsg{agents: testFleet(21), stats: Stats{Sessions: 11, Beacons: 10}})
+	if m.contentHash == before {
+		t.Error("a new agent did not update the viewport content")
+	}
+}
+
+// BenchmarkPulseTickUnchangedFleet reports how many pulse ticks on a static
+// 500-agent fleet still reach SetContent (setcontent/op). Every tick marks
+// the content dirty and re-renders it, so without the content hash check
+// each one would.
+func BenchmarkPulseTickUnchangedFleet(b *testing.B) {
+	m := newTestModel()
+	m = update(b, m, tea.WindowSizeMsg{Width: 180, Height: 50})
+	m = update(b, m, agentsMsg{agents: testFleet(500), stats: Stats{Sessions: 250, Beacons: 250}})
+	b.ResetTimer()
+	_, sets := pulseSetContents(b, m, b.N)
+	b.ReportMetric(float64(sets)/float64(b.N), "setcontent/op")
+}
+
+// BenchmarkViewportSetContent is the per-tick cost the skip saves on a
+// 500-agent fleet
+func BenchmarkViewportSetContent(b *testing.B) {
+	m := newTestModel()
+	m = update(b, m, tea.WindowSizeMsg{Width: 180, Height: 50})
+	m = update(b, m, agentsMsg{agents: testFleet(500), stats: Stats{Sessions: 250, Beacons: 250}})
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.viewport.SetContent(m.cachedContent)
+	}
+}
+
+func TestFailedRefreshesKeepLastGoodAgents(t *testing.T) {
+	m := newTestModel()
+	m = update(t, m, tea.WindowSizeMsg{Width: 180, Height: 40})
+	fleet := []Agent{
+		{ID: "beacon-1", Hostname: "WS01", RemoteAddress: "10.0.0.5:443", OS: "windows", Transport: "mtls"},
+		{ID: "beacon-2", Hostname: "WS02", RemoteAddress: "10.0.0.6:443", OS: "linux", Transport: "http"},
+	}
+	m = update(t, m, agentsMsg{agents: fleet, stats: Stats{Beacons: 2, Hosts: 2, Compromised: 2}})
+	if len(m.agents) != 2 {
+		t.Fatalf("after agentsMsg m.agents has %d agents, want 2", len(m.agents))
+	}
+
+	for i := 0; i < 3; i++ {
+		m = update(t, m, errMsg{err: errors.New("connection refused")})
+	}
+
+	if m.err == nil {
+		t.Error("m.err not set after failed refreshes")
+	}
+	if len(m.agents) != 2 || m.agents[0].ID != "beacon-1" || m.agents[1].ID != "beacon-2" {
+		t.Errorf("m.agents after failures = %v, want the last good fleet", m.agents)
+	}
+	if len(m.allAgents) != 2 || len(m.previousAgents) != 2 {
+		t.Errorf("allAgents/previousAgents = %d/%d after failures, want 2/2", len(m.allAgents), len(m.previousAgents))
+	}
+	if m.stats.Compromised != 2 {
+		t.Errorf("m.stats.Compromised = %d after failures, want 2", m.stats.Compromised)
+	}
+
+	// The operator sees the stale data warning over the old board, not a blank one
+	view := ansi.Strip(m.View())
+	if !strings.Contains(view, "showing stale data") {
+		t.Error("view has no stale data warning")
+	}
+	if strings.Contains(view, "No agents connected") || !strings.Contains(view, "WS01") {
+		t.Error("view blanked the agent list during the outage")
+	}
+
+	// Errors for a server we've switched away from don't touch the board
+	m.err = nil
+	m = update(t, m, errMsg{err: errors.New("old server"), configPath: "/old/server.cfg"})
+	if m.err != nil {
+		t.Error("error from a stale server config was applied")
+	}
+}
+
+func TestSameIDReconnectRaisesOneAlert(t *testing.T) {
+	m := newTestModel()
+	session := Agent{ID: "session-1", Hostname: "WS01", RemoteAddress: "10.0.0.5:443", IsSession: true}
+	other := Agent{ID: "beacon-2", Hostname: "WS02", RemoteAddress: "10.0.0.6:443"}
+	refreshAgents(&m, session, other)
+	m.alertManager.ClearAll()
+
+	// Drop: the lost alert is held for the grace period
+	refreshAgents(&m, other)
+	if got := alertCategories(&m); len(got) != 0 {
+		t.Fatalf("alerts on drop = %v, want none yet", got)
+	}
+
+	// Reconnect under the same ID within the grace period
+	refreshAgents(&m, session, other)
+	got := alertCategories(&m)
+	if len(got) != 1 || got[0] != alerts.CategoryAgentReconnected {
+		t.Fatalf("alerts on reconnect = %v, want one %v", got, alerts.CategoryAgentReconnected)
+	}
+	if len(m.pendingLost) != 0 {
+		t.Errorf("reconnected agent still held as lost: %v", m.pendingLost)
+	}
+
+	// An agent still gone after the grace period is reported lost
+	m.alertManager.ClearAll()
+	refreshAgents(&m, other)
+	lost := m.pendingLost[session.ID]
+	lost.lostAt = time.Now().Add(-lostGracePeriod)
+	m.pendingLost[session.ID] = lost
+	refreshAgents(&m, other)
+	got = alertCategories(&m)
+	if len(got) != 1 || got[0] != alerts.CategorySessionDisconnected {
+		t.Errorf("alerts after grace period = %v, want one %v", got, alerts.CategorySessionDisconnected)
+	}
+}
+
+func TestPrivilegedFlapRaisesNoFleetAlert(t *testing.T) {
+	m := newTestModel()
+	admin := Agent{ID: "session-1", Hostname: "DC01", RemoteAddress: "10.0.0.5:443", IsSession: true, IsPrivileged: true}
+	other := Agent{ID: "beacon-2", Hostname: "WS02", RemoteAddress: "10.0.0.6:443"}
+	refreshAgents(&m, admin, other)
+	m.alertManager.ClearAll()
+
+	// The only privileged session flaps inside the grace period
+	refreshAgents(&m, other)
+	refreshAgents(&m, admin, other)
+	got := alertCategories(&m)
+	if len(got) != 1 || got[0] != alerts.CategoryAgentReconnected {
+		t.Fatalf("alerts on flap = %v, want one %v", got, alerts.CategoryAgentReconnected)
+	}
+
+	// Still gone after the grace period: lost, then no privilege remaining
+	m.alertManager.ClearAll()
+	refreshAgents(&m, other)
+	if got := alertCategories(&m); len(got) != 0 {
+		t.Fatalf("alerts on drop = %v, want none yet", got)
+	}
+	lost := m.pendingLost[admin.ID]
+	lost.lostAt = time.Now().Add(-lostGracePeriod)
+	m.pendingLost[admin.ID] = lost
+	refreshAgents(&m, other)
+	got = alertCategories(&m)
+	if len(got) != 2 || !containsCategory(got, alerts.CategoryPrivilegeLost) {
+		t.Fatalf("alerts after grace period = %v, want the lost session and %v", got, alerts.CategoryPrivilegeLost)
+	}
+
+	// An acknowledged privileged agent raises neither fleet alert
+	m.alertManager.ClearAll()
+	m.ackedAgents[admin.ID] = time.Now().Add(ackDuration)
+	refreshAgents(&m, admin, other)
+	refreshAgents(&m, other)
+	if got := alertCategories(&m); len(got) != 0 {
+		t.Errorf("alerts for an acknowledged agent = %v, want none", got)
+	}
+}
+
+// containsCategory reports whether want is among the alert categories
+func containsCategory(categories []alerts.AlertCategory, want alerts.AlertCategory) bool {
+	for _, category := range categories {
+		if category == want {
+			return true
+		}
+	}
+	return false
+}
+
+func TestCompromiseRateCountsArrivalsOnce(t *testing.T) {
+	// One agent arrives and stays NEW for 5 minutes of 5-second refreshes
+	// (60 samples), then nothing else happens for the rest of the hour
+	start := time.Now().Add(-time.Hour)
+	var samples []ActivitySample
+	for i := 0; i <= 720; i++ {
+		sample := ActivitySample{Timestamp: start.Add(time.Duration(i) * 5 * time.Second)}
+		if i >= 1 && i <= 60 {
+			sample.NewCount = 1
+		}
+		if i == 1 {
+			sample.Arrivals = 1
+		}
+		samples = append(samples, sample)
+	}
+
+	stats := calculateActivityStats(samples)
+	if stats.CompromiseRate < 0.99 || stats.CompromiseRate > 1.01 {
+		t.Errorf("CompromiseRate = %.2f/h, want 1/h", stats.CompromiseRate)
+	}
+	if got := sampleMetricValue(samples, 100, "rate"); got != 1 {
+		t.Errorf("trailing-hour rate at sample 100 = %d, want 1", got)
+	}
+}
+
+func TestSampleArrivals(t *testing.T) {
+	tracker := NewActivityTracker()
+	agents := []models.Agent{{ID: "a", FirstSeen: time.Now()}}
+	tracker.SampleCurrentActivity(agents, models.Stats{Beacons: 1})
+
+	// The same agent on the next refresh is not a new arrival; a newcomer is
+	time.Sleep(time.Millisecond)
+	agents = append(agents, models.Agent{ID: "b", FirstSeen: time.Now(), IsNew: true})
+	tracker.SampleCurrentActivity(agents, models.Stats{Beacons: 2})
+	tracker.SampleCurrentActivity(agents, models.Stats{Beacons: 2})
+
+	samples := tracker.GetSamples()
+	got := []int{samples[0].Arrivals, samples[1].Arrivals, samples[2].Arrivals}
+	if got[0] != 0 || got[1] != 1 || got[2] != 0 {
+		t.Errorf("arrivals per sample = %v, want [0 1 0]", got)
+	}
+}
+
+func TestFooterStatsFitNarrowTerminal(t *testing.T) {
+	m := newTestModel()
+	m = update(t, m, tea.WindowSizeMsg{Width: 90, Height: 40})
+	m.tracker.TrackAgentChanges([]Agent{{ID: "gone-1"}, {ID: "gone-2"}})
+	m.tracker.TrackAgentChanges(nil) // Both lost
+	fleet := []Agent{{ID: "8f14e45f-ceea-467f-a7a0-6c1e0d7f6b21", Hostname: "WS01", RemoteAddress: "10.0.0.5:443"}}
+	for i := 0; i < 3; i++ {
+		m = update(t, m, agentsMsg{agents: fleet, stats: Stats{Sessions: 120 + i, Beacons: 340 + i, Privileged: 45 + i, Compromised: 460 + 2*i}})
+	}
+
+	// Widest case: lost agents, trend arrows and 3-digit counts
+	for _, line := range strings.Split(m.View(), "\n") {
+		if !strings.Contains(line, "Lost: 2") {
+			continue
+		}
+		if width := ansi.StringWidth(line); width > 80 {
+			t.Errorf("footer stats line is %d cells, want at most 80:\n%s", width, ansi.Strip(line))
+		}
+		if !strings.HasSuffix(ansi.Strip(line), "│") {
+			t.Errorf("footer stats line lost its right border:\n%s", ansi.Strip(line))
+		}
+		return
+	}
+	t.Error("no footer stats line shows the lost counter")
+}
+
+func TestExpiredAcksPrunedOnRefresh(t *testing.T) {
+	m := newTestModel()
+	agent := Agent{ID: "acked-agent-1", Hostname: "acked", IsSession: true}
+	m.ackedAgents[agent.ID] = time.Now().Add(-time.Second)
+
+	// Rendering must not mutate the ack map
+	if m.isAcked(agent.ID) || m.ackBadge(agent) != "" {
+		t.Fatal("expired ack still reported as acknowledged")
+	}
+	if _, ok := m.ackedAgents[agent.ID]; !ok {
+		t.Fatal("isAcked deleted the expired entry; only Update should prune")
+	}
+
+	m = update(t, m, agentsMsg{agents: []Agent{agent}, configPath: m.clientOpts.ConfigPath})
+	if _, ok := m.ackedAgents[agent.ID]; ok {
+		t.Error("expired ack survived an agents refresh")
+	}
+}
+
+func TestActivityPanelsEmptyState(t *testing.T) {
+	m := newTestModel()
+	for name, panel := range map[string]string{
+		"activity":  ansi.Strip(m.renderSparklinePanel()),
+		"transport": ansi.Strip(m.renderTransportSparklinePanel()),
+	} {
+		if !strings.Contains(panel, "Collecting samples (every 10m)") {
+			t.Errorf("%s panel empty state = %q, want the real sample interval", name, panel)
+		}
+		if !strings.Contains(panel, "12h") {
+			t.Errorf("%s panel = %q, want the 12h window in the title", name, panel)
+		}
+	}
+}